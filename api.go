@@ -1,81 +1,112 @@
 package time
 
+import "sync/atomic"
+
 // Timer represents a cancelable timer.
 type Timer interface {
 	// Stop prevents the timer from firing. Returns true if the timer was active.
 	Stop() bool
+	// Reset changes the timer to fire after milliseconds from now, reusing the
+	// same callback. Returns true if the timer had been active, false if it
+	// had expired or been stopped (as stdlib Timer.Reset).
+	Reset(milliseconds int) bool
+	// Pending reports whether the timer is armed and has not fired yet.
+	Pending() bool
+	// Remaining returns the milliseconds left until the timer fires, or 0
+	// when it is not pending.
+	Remaining() int
+}
+
+// Ticker represents a repeating timer.
+type Ticker interface {
+	// Stop stops the ticks. Returns true if the ticker was active.
+	Stop() bool
+	// Reset changes the period to milliseconds and restarts the ticks from
+	// now, reactivating a stopped ticker.
+	Reset(milliseconds int)
 }
 
-// Now retrieves the current Unix timestamp in nanoseconds in UTC.
+// Now retrieves the current Unix timestamp in nanoseconds in UTC from the active Clock.
 func Now() int64 {
-	return provider.UnixNano()
+	return GetClock().Now()
+}
+
+// Monotonic returns a monotonic clock reading in nanoseconds from an
+// arbitrary origin. Unlike Now it never jumps when the system clock is
+// changed, so use it to measure elapsed time, never as a date.
+// Backend: Go's monotonic clock. WASM: performance.now(), whose
+// resolution the browser may coarsen to 5-100 microseconds.
+func Monotonic() int64 {
+	return GetClock().Monotonic()
 }
 
 // FormatTime formats a value into a time string "HH:MM:SS" applying the timezone offset.
 func FormatTime(value any) string {
-	return provider.FormatTime(value)
+	return provider().FormatTime(value)
 }
 
 // FormatDate formats a value into a date string "YYYY-MM-DD" applying the timezone offset.
 func FormatDate(value any) string {
-	return provider.FormatDate(value)
+	return provider().FormatDate(value)
 }
 
 // FormatDateTime formats a value into a date-time string "YYYY-MM-DD HH:MM:SS" applying the timezone offset.
 func FormatDateTime(value any) string {
-	return provider.FormatDateTime(value)
+	return provider().FormatDateTime(value)
 }
 
 // FormatDateTimeShort formats a value into a short date-time string "YYYY-MM-DD HH:MM".
 func FormatDateTimeShort(value any) string {
-	return provider.FormatDateTimeShort(value)
+	return provider().FormatDateTimeShort(value)
 }
 
 // FormatISO8601 formats a UnixNano timestamp into an ISO 8601 string (UTC).
 // Format: "YYYY-MM-DDTHH:MM:SSZ"
 func FormatISO8601(nano int64) string {
-	return provider.FormatISO8601(nano)
+	return provider().FormatISO8601(nano)
 }
 
 // FormatCompact formats a UnixNano timestamp into a compact string "YYYYMMDDHHmmss" (UTC).
 // Useful for PDF metadata dates, file naming, and compact timestamps.
 func FormatCompact(nano int64) string {
-	return provider.FormatCompact(nano)
+	return provider().FormatCompact(nano)
 }
 
 // ParseDate parses a date string ("YYYY-MM-DD") into a UnixNano timestamp (UTC).
 func ParseDate(dateStr string) (int64, error) {
-	return provider.ParseDate(dateStr)
+	return provider().ParseDate(dateStr)
 }
 
 // ParseTime parses a time string into minutes since midnight (UTC).
 func ParseTime(timeStr string) (int16, error) {
-	return provider.ParseTime(timeStr)
+	return provider().ParseTime(timeStr)
 }
 
 // ParseDateTime combines date and time strings into a single UnixNano timestamp (UTC).
 func ParseDateTime(dateStr, timeStr string) (int64, error) {
-	return provider.ParseDateTime(dateStr, timeStr)
+	return provider().ParseDateTime(dateStr, timeStr)
 }
 
 // IsToday checks if the given UnixNano timestamp is today according to the current timezone offset.
 func IsToday(nano int64) bool {
-	return provider.IsToday(nano)
+	return DateOf(nano) == Today()
 }
 
 // IsPast checks if the given UnixNano timestamp is in the past.
 func IsPast(nano int64) bool {
-	return provider.IsPast(nano)
+	return nano < Now()
 }
 
 // IsFuture checks if the given UnixNano timestamp is in the future.
 func IsFuture(nano int64) bool {
-	return provider.IsFuture(nano)
+	return nano > Now()
 }
 
-// DaysBetween calculates the number of full days between two UnixNano timestamps.
+// DaysBetween returns the number of calendar days between two UnixNano
+// timestamps, comparing their local dates in the active timezone offset:
+// yesterday 23:00 to today 01:00 is 1 day. Negative if nano2 is before nano1.
 func DaysBetween(nano1, nano2 int64) int {
-	return DaysBetweenShared(nano1, nano2)
+	return DateOf(nano2).DaysSince(DateOf(nano1))
 }
 
 // Weekday returns the day of the week (0=Sunday … 6=Saturday) for a Unix
@@ -105,17 +136,31 @@ func MidnightUTC(unixSec int64) int64 {
 // localMinutes is minutes elapsed since midnight in the local timezone.
 // tz is an IANA timezone name (e.g. "America/New_York"); falls back to UTC if invalid.
 func LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz string) int64 {
-	return provider.LocalMinutesToUnixUTC(dateSec, localMinutes, tz)
+	return provider().LocalMinutesToUnixUTC(dateSec, localMinutes, tz)
 }
 
 // AfterFunc waits for the specified milliseconds then calls f.
 func AfterFunc(milliseconds int, f func()) Timer {
-	return provider.AfterFunc(milliseconds, f)
+	return GetClock().AfterFunc(milliseconds, f)
 }
 
-// Internal interface for the singleton provider
-type timeProvider interface {
+// TickFunc calls f every milliseconds until the Ticker is stopped.
+// Backend: a time.Ticker drives a goroutine; ticks that come while f is
+// still running are dropped. WASM: setInterval on the JS event loop.
+func TickFunc(milliseconds int, f func()) Ticker {
+	return GetClock().TickFunc(milliseconds, f)
+}
+
+// Provider is the platform layer behind the package: the wall clock,
+// display formatting, IANA zone offsets and timers. Each build has a
+// default (stdlib on the backend, the JS Date API in the browser);
+// SetProvider plugs in another runtime. Embed
+// PortableProvider to get pure-Go formatting and parsing.
+type Provider interface {
 	UnixNano() int64
+	// Monotonic returns nanoseconds from an arbitrary fixed origin,
+	// unaffected by system clock changes.
+	Monotonic() int64
 	FormatDate(value any) string
 	FormatTime(value any) string
 	FormatDateTime(value any) string
@@ -125,11 +170,32 @@ type timeProvider interface {
 	ParseDate(dateStr string) (int64, error)
 	ParseTime(timeStr string) (int16, error)
 	ParseDateTime(dateStr, timeStr string) (int64, error)
-	IsToday(nano int64) bool
-	IsPast(nano int64) bool
-	IsFuture(nano int64) bool
 	LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz string) int64
+	// ZoneOffset returns the offset in seconds east of UTC of the IANA zone
+	// tz at unixSec, or false if the zone is unknown.
+	ZoneOffset(tz string, unixSec int64) (int, bool)
 	AfterFunc(milliseconds int, f func()) Timer
+	TickFunc(milliseconds int, f func()) Ticker
+}
+
+type providerBox struct{ p Provider }
+
+var activeProvider atomic.Pointer[providerBox]
+
+func init() {
+	activeProvider.Store(&providerBox{newDefaultProvider()})
 }
 
-var provider timeProvider
+// provider returns the active Provider.
+func provider() Provider {
+	return activeProvider.Load().p
+}
+
+// SetProvider replaces the platform provider; nil restores the build's
+// default. It is safe to call while timers are running.
+func SetProvider(p Provider) {
+	if p == nil {
+		p = newDefaultProvider()
+	}
+	activeProvider.Store(&providerBox{p})
+}
@@ -0,0 +1,110 @@
+package time
+
+// Overflow selects how calendar arithmetic resolves a day that does not
+// exist in the target month, such as January 31 plus one month.
+type Overflow int
+
+const (
+	// Clamp moves to the last day of the month: Jan 31 + 1 month = Feb 28 (29).
+	Clamp Overflow = iota
+	// Roll carries the excess days into the next month: Jan 31 + 1 month = Mar 2 (3).
+	// This matches the standard library time.AddDate.
+	Roll
+)
+
+// AddDate adds years, months and days to a UnixNano timestamp in the active
+// timezone offset, keeping the local time of day. Years and months are
+// applied first and resolved with overflow; days are added last.
+func AddDate(nano int64, years, months, days int, overflow Overflow) int64 {
+	return TimeOf(nano).AddDate(years, months, days, overflow).UnixNano()
+}
+
+// AddDate adds years, months and days to t in its location, keeping the wall
+// clock time of day (see Date.AddDate). Times that fall into a DST gap move
+// forward by the length of the gap.
+func (t Time) AddDate(years, months, days int, overflow Overflow) Time {
+	dt := t.DateTime()
+	dt.Date = dt.Date.AddDate(years, months, days, overflow)
+	t.nano, _ = dt.UnixNanoIn(t.loc, Compatible)
+	return t
+}
+
+// MonthsBetween returns the number of complete calendar months between the
+// local dates of two UnixNano timestamps (active timezone offset).
+func MonthsBetween(nano1, nano2 int64) int {
+	return DateOf(nano2).MonthsSince(DateOf(nano1))
+}
+
+// YearsBetween returns the number of complete calendar years between the
+// local dates of two UnixNano timestamps, e.g. an age in years.
+func YearsBetween(nano1, nano2 int64) int {
+	return MonthsBetween(nano1, nano2) / 12
+}
+
+// PeriodBetween returns the years, months and days between the local dates
+// of two UnixNano timestamps (active timezone offset).
+func PeriodBetween(nano1, nano2 int64) Period {
+	return DateOf(nano2).PeriodSince(DateOf(nano1))
+}
+
+// Period is a calendar difference expressed in years, months and days.
+// All fields share the same sign.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// String formats p as an ISO 8601 duration such as "P1Y2M3D" ("P0D" when empty).
+func (p Period) String() string {
+	buf := make([]byte, 0, 16)
+	if p.Years < 0 || p.Months < 0 || p.Days < 0 {
+		buf = append(buf, '-')
+		p = Period{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+	}
+	buf = append(buf, 'P')
+	if p.Years != 0 {
+		buf = append(appendInt(buf, p.Years, 0), 'Y')
+	}
+	if p.Months != 0 {
+		buf = append(appendInt(buf, p.Months, 0), 'M')
+	}
+	if p.Days != 0 || len(buf) <= 2 {
+		buf = append(appendInt(buf, p.Days, 0), 'D')
+	}
+	return string(buf)
+}
+
+// MonthsSince returns the number of complete months from o to d. A month is
+// complete when o.AddMonths(n) does not pass d, so Jan 31 to Feb 29 counts
+// as one month. Negative if d is before o.
+func (d Date) MonthsSince(o Date) int {
+	if d.Before(o) {
+		return -o.MonthsSince(d)
+	}
+	months := (d.Year-o.Year)*12 + int(d.Month-o.Month)
+	if o.AddMonths(months).After(d) {
+		months--
+	}
+	return months
+}
+
+// YearsSince returns the number of complete years from o to d, e.g. an age.
+func (d Date) YearsSince(o Date) int {
+	return d.MonthsSince(o) / 12
+}
+
+// PeriodSince returns the years, months and days from o to d, for displays
+// such as "1 year 2 months 3 days". Negative if d is before o.
+func (d Date) PeriodSince(o Date) Period {
+	if d.Before(o) {
+		p := o.PeriodSince(d)
+		return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+	}
+	months := d.MonthsSince(o)
+	return Period{
+		Years:  months / 12,
+		Months: months % 12,
+		Days:   d.DaysSince(o.AddMonths(months)),
+	}
+}
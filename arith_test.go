@@ -0,0 +1,113 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test AddDate
+func AddDateShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+
+	at := func(s string) int64 {
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dt.UnixNanoOffset(-3 * 3600)
+	}
+	cases := []struct {
+		from                string
+		years, months, days int
+		overflow            time.Overflow
+		want                string
+	}{
+		{"2024-01-31 22:00", 0, 1, 0, time.Clamp, "2024-02-29 22:00"},
+		{"2024-01-31 22:00", 0, 1, 0, time.Roll, "2024-03-02 22:00"},
+		{"2023-01-31 08:00", 0, 1, 0, time.Roll, "2023-03-03 08:00"},
+		{"2024-02-29 10:00", 1, 0, 0, time.Clamp, "2025-02-28 10:00"},
+		{"2024-02-29 10:00", 1, 0, 0, time.Roll, "2025-03-01 10:00"},
+		{"2024-03-31 23:30", 0, -1, 1, time.Clamp, "2024-03-01 23:30"},
+		{"2024-12-31 21:00", 0, 0, 1, time.Clamp, "2025-01-01 21:00"},
+	}
+	for _, c := range cases {
+		got := time.AddDate(at(c.from), c.years, c.months, c.days, c.overflow)
+		if time.FormatDateTimeShort(got) != c.want {
+			t.Errorf("AddDate(%s, %d, %d, %d, %d) = %s; want %s", c.from, c.years, c.months, c.days, c.overflow, time.FormatDateTimeShort(got), c.want)
+		}
+	}
+
+	// Wall clock is kept across a DST transition in a named zone
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt, _ := time.ParseCivilDateTime("2024-03-09 09:00")
+	nano, _ := dt.UnixNanoIn(ny, time.Compatible)
+	next := time.TimeOf(nano).In(ny).AddDate(0, 0, 1, time.Clamp)
+	if got := next.Format("2006-01-02 15:04 -07:00"); got != "2024-03-10 09:00 -04:00" {
+		t.Errorf("Time.AddDate across DST = %s", got)
+	}
+	if next.Sub(time.TimeOf(nano)) != 23*3600*1000000000 {
+		t.Errorf("DST day should last 23h, got %d", next.Sub(time.TimeOf(nano)))
+	}
+}
+
+// Test calendar differences
+func BetweenShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+
+	const h = int64(3600 * 1000000000)
+	// 2024-01-15 00:00 UTC-3
+	base := time.Date{Year: 2024, Month: time.January, Day: 15}.UnixNano()
+
+	// Yesterday 23:00 to today 01:00 is one calendar day
+	if got := time.DaysBetween(base-1*h, base+1*h); got != 1 {
+		t.Errorf("DaysBetween(23:00, 01:00) = %d; want 1", got)
+	}
+	if got := time.DaysBetween(base+1*h, base-1*h); got != -1 {
+		t.Errorf("DaysBetween(reversed) = %d; want -1", got)
+	}
+	if got := time.DaysBetweenShared(base-1*h, base+1*h); got != 0 {
+		t.Errorf("DaysBetweenShared(2h) = %d; want 0", got)
+	}
+	// 02:00 UTC is still the previous local day in UTC-3
+	if got := time.DaysBetween(base+1*h, base+26*h); got != 1 {
+		t.Errorf("DaysBetween(local dates) = %d; want 1", got)
+	}
+
+	birth := time.Date{Year: 2022, Month: time.August, Day: 31}
+	on := time.Date{Year: 2024, Month: time.February, Day: 29}
+	if got := time.MonthsBetween(birth.UnixNano(), on.UnixNano()); got != 18 {
+		t.Errorf("MonthsBetween = %d; want 18", got)
+	}
+	if got := time.YearsBetween(birth.UnixNano(), on.UnixNano()); got != 1 {
+		t.Errorf("YearsBetween = %d; want 1", got)
+	}
+	p := time.PeriodBetween(birth.UnixNano(), on.UnixNano()+23*h)
+	if p != (time.Period{Years: 1, Months: 6, Days: 0}) || p.String() != "P1Y6M" {
+		t.Errorf("PeriodBetween = %+v (%s)", p, p)
+	}
+
+	cases := []struct {
+		from, to time.Date
+		want     string
+	}{
+		{time.Date{Year: 2024, Month: 1, Day: 31}, time.Date{Year: 2024, Month: 2, Day: 29}, "P1M"},
+		{time.Date{Year: 2024, Month: 1, Day: 31}, time.Date{Year: 2024, Month: 2, Day: 28}, "P28D"},
+		{time.Date{Year: 2020, Month: 2, Day: 29}, time.Date{Year: 2021, Month: 2, Day: 28}, "P1Y"},
+		{time.Date{Year: 2023, Month: 11, Day: 20}, time.Date{Year: 2024, Month: 1, Day: 5}, "P1M16D"},
+		{time.Date{Year: 2024, Month: 1, Day: 5}, time.Date{Year: 2023, Month: 11, Day: 20}, "-P1M16D"},
+		{on, on, "P0D"},
+	}
+	for _, c := range cases {
+		if got := c.to.PeriodSince(c.from).String(); got != c.want {
+			t.Errorf("PeriodSince(%s → %s) = %s; want %s", c.from, c.to, got, c.want)
+		}
+	}
+}
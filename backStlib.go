@@ -9,8 +9,8 @@ import (
 	. "github.com/tinywasm/fmt"
 )
 
-func init() {
-	provider = &timeServer{}
+func newDefaultProvider() Provider {
+	return &timeServer{}
 }
 
 // timeServer implements timeProvider for standard Go.
@@ -20,6 +20,16 @@ func (ts *timeServer) UnixNano() int64 {
 	return time.Now().UTC().UnixNano()
 }
 
+// minTime and maxTime bound the instants UnixNano can represent.
+var minTime, maxTime = time.Unix(0, minUnixNano).UTC(), time.Unix(0, maxUnixNano).UTC()
+
+// monoStart anchors Monotonic; time.Since uses the monotonic reading.
+var monoStart = time.Now()
+
+func (ts *timeServer) Monotonic() int64 {
+	return int64(time.Since(monoStart))
+}
+
 func (ts *timeServer) applyOffset(t time.Time) time.Time {
 	offset := getOffsetMinutes()
 	return t.Add(time.Duration(offset) * time.Minute)
@@ -100,6 +110,9 @@ func (ts *timeServer) ParseDate(dateStr string) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if t.Before(minTime) || t.After(maxTime) {
+		return 0, Errf("date out of range: %s", dateStr)
+	}
 	return t.UnixNano(), nil
 }
 
@@ -116,25 +129,12 @@ func (ts *timeServer) ParseDateTime(dateStr, timeStr string) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if t.Before(minTime) || t.After(maxTime) {
+		return 0, Errf("date out of range: %s %s", dateStr, timeStr)
+	}
 	return t.UnixNano(), nil
 }
 
-func (ts *timeServer) IsToday(nano int64) bool {
-	t := time.Unix(0, nano).UTC()
-	now := time.Now().UTC()
-	tLocal := ts.applyOffset(t)
-	nowLocal := ts.applyOffset(now)
-	return tLocal.Year() == nowLocal.Year() && tLocal.YearDay() == nowLocal.YearDay()
-}
-
-func (ts *timeServer) IsPast(nano int64) bool {
-	return nano < ts.UnixNano()
-}
-
-func (ts *timeServer) IsFuture(nano int64) bool {
-	return nano > ts.UnixNano()
-}
-
 func (ts *timeServer) LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz string) int64 {
 	loc, err := time.LoadLocation(tz)
 	if err != nil {
@@ -147,15 +147,14 @@ func (ts *timeServer) LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz
 	return localTime.Unix()
 }
 
-type timerWrapper struct {
-	timer *time.Timer
+func (ts *timeServer) ZoneOffset(tz string, unixSec int64) (int, bool) {
+	return stdZoneOffset(tz, unixSec)
 }
 
-func (tw *timerWrapper) Stop() bool {
-	return tw.timer.Stop()
+func (ts *timeServer) AfterFunc(milliseconds int, f func()) Timer {
+	return newStdTimer(milliseconds, f)
 }
 
-func (ts *timeServer) AfterFunc(milliseconds int, f func()) Timer {
-	t := time.AfterFunc(time.Duration(milliseconds)*time.Millisecond, f)
-	return &timerWrapper{timer: t}
+func (ts *timeServer) TickFunc(milliseconds int, f func()) Ticker {
+	return newStdTicker(milliseconds, f)
 }
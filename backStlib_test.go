@@ -4,9 +4,41 @@ package time_test
 
 import (
 	"testing"
+	stlib "time"
+
+	"github.com/tinywasm/time"
 )
 
 // TestTimeAPIBackend tests the standard Go time API implementation.
 func TestTimeAPIBackend(t *testing.T) {
 	RunAPITests(t)
 }
+
+func TestTimeStdConversion(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := time.TimeOf(1705332645123456789).In(ny)
+
+	st := tm.Std()
+	if st.UnixNano() != tm.UnixNano() || st.Location().String() != "America/New_York" {
+		t.Errorf("Std() = %v", st)
+	}
+	if st.Format("2006-01-02 15:04:05") != tm.Format("2006-01-02 15:04:05") {
+		t.Errorf("Std wall clock %s differs from %s", st.Format("2006-01-02 15:04:05"), tm.Format("2006-01-02 15:04:05"))
+	}
+
+	back := time.FromStd(st)
+	if !back.Equal(tm) || back.Location().String() != "America/New_York" {
+		t.Errorf("FromStd(Std()) = %v in %s", back, back.Location())
+	}
+	if loc := time.FromStd(stlib.Unix(0, 0).In(stlib.FixedZone("CLT", -4*3600))).Location(); loc.String() != "CLT" || loc.Offset(0) != -4*3600 {
+		t.Errorf("FromStd(fixed zone) location = %s %d", loc, loc.Offset(0))
+	}
+	// A fixed zone named like a tz database zone keeps its own offset.
+	cet := stlib.Date(2024, 7, 1, 12, 0, 0, 0, stlib.FixedZone("CET", 3600))
+	if got := time.FromStd(cet); got.Format("15:04 -07:00") != "12:00 +01:00" || got.Location().String() != "CET" {
+		t.Errorf("FromStd(fixed CET) = %s in %s", got.Format("15:04 -07:00"), got.Location())
+	}
+}
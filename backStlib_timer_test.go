@@ -40,3 +40,118 @@ func TestAfterFunc_Stop(t *testing.T) {
 	stlib.Sleep(200 * stlib.Millisecond)
 	AfterFuncStopVerify(t, executed)
 }
+
+func TestTickFunc(t *testing.T) {
+	var ticks atomic.Int32
+	ticker := time.TickFunc(20, func() { ticks.Add(1) })
+	stlib.Sleep(110 * stlib.Millisecond)
+	if !ticker.Stop() || ticker.Stop() {
+		t.Error("Stop() should return true once")
+	}
+	got := ticks.Load()
+	if got < 3 || got > 6 {
+		t.Errorf("ticks = %d; want about 5", got)
+	}
+	stlib.Sleep(60 * stlib.Millisecond)
+	if ticks.Load() != got {
+		t.Error("ticker ticked after Stop")
+	}
+
+	ticker.Reset(10)
+	stlib.Sleep(55 * stlib.Millisecond)
+	ticker.Stop()
+	if n := ticks.Load() - got; n < 3 || n > 6 {
+		t.Errorf("ticks after Reset = %d; want about 5", n)
+	}
+}
+
+func TestTickFunc_ZeroPeriod(t *testing.T) {
+	var ticks atomic.Int32
+	ticker := time.TickFunc(0, func() { ticks.Add(1) })
+	stlib.Sleep(30 * stlib.Millisecond)
+	ticker.Reset(-1)
+	stlib.Sleep(30 * stlib.Millisecond)
+	ticker.Stop()
+	if got := ticks.Load(); got < 5 {
+		t.Errorf("ticks = %d; want a tick about every millisecond", got)
+	}
+}
+
+func TestTickFuncCompensated(t *testing.T) {
+	var ticks atomic.Int32
+	start := time.Now()
+	var last atomic.Int64
+	ticker := time.TickFuncCompensated(20, func() {
+		ticks.Add(1)
+		last.Store(time.Now())
+		stlib.Sleep(8 * stlib.Millisecond) // slow callbacks do not delay the grid
+	})
+	stlib.Sleep(210 * stlib.Millisecond)
+	ticker.Stop()
+	if n := ticks.Load(); n < 9 || n > 10 {
+		t.Errorf("ticks = %d; want 10", n)
+	}
+	// The last tick lands close to its grid point.
+	offset := (last.Load() - start) % 20e6
+	if offset > 5e6 && offset < 15e6 {
+		t.Errorf("tick drifted %dns from the grid", offset)
+	}
+
+	// Missed ticks are dropped, not bursted.
+	ticks.Store(0)
+	ticker = time.TickFuncCompensated(10, func() {
+		if ticks.Add(1) == 1 {
+			stlib.Sleep(55 * stlib.Millisecond)
+		}
+	})
+	stlib.Sleep(100 * stlib.Millisecond)
+	ticker.Stop()
+	if n := ticks.Load(); n > 6 {
+		t.Errorf("ticks = %d; missed ticks should be dropped", n)
+	}
+}
+
+func TestAfterFunc_Reset(t *testing.T) {
+	var fired atomic.Int32
+	timer := time.AfterFunc(40, func() { fired.Add(1) })
+	if !timer.Pending() {
+		t.Error("new timer should be pending")
+	}
+	if r := timer.Remaining(); r < 30 || r > 40 {
+		t.Errorf("Remaining = %d; want about 40", r)
+	}
+
+	// Pushing the deadline back, as a batching broker does on every packet.
+	for range 4 {
+		stlib.Sleep(20 * stlib.Millisecond)
+		if !timer.Reset(40) {
+			t.Error("Reset of a pending timer should return true")
+		}
+	}
+	if fired.Load() != 0 {
+		t.Error("timer fired although it kept being reset")
+	}
+	stlib.Sleep(70 * stlib.Millisecond)
+	if fired.Load() != 1 || timer.Pending() || timer.Remaining() != 0 {
+		t.Errorf("fired = %d, pending = %v", fired.Load(), timer.Pending())
+	}
+
+	// Reset after expiry re-arms the same callback.
+	if timer.Reset(10) {
+		t.Error("Reset of an expired timer should return false")
+	}
+	stlib.Sleep(40 * stlib.Millisecond)
+	if fired.Load() != 2 {
+		t.Errorf("fired = %d; want 2", fired.Load())
+	}
+
+	timer.Reset(10)
+	timer.Stop()
+	if timer.Pending() {
+		t.Error("stopped timer should not be pending")
+	}
+	stlib.Sleep(30 * stlib.Millisecond)
+	if fired.Load() != 2 {
+		t.Error("stopped timer fired")
+	}
+}
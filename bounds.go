@@ -0,0 +1,121 @@
+package time
+
+// CalendarUnit is a calendar period used by StartOf and EndOf.
+type CalendarUnit int
+
+const (
+	UnitHour CalendarUnit = iota
+	UnitDay
+	UnitWeek // starts on FirstDayOfWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+// StartOf returns the first instant of the unit that contains nano, using
+// wall-clock boundaries in loc. A boundary skipped by a DST gap moves to
+// the first existing instant (e.g. 01:00 when midnight does not exist).
+func StartOf(nano int64, unit CalendarUnit, loc *Location) int64 {
+	return startOf(nano, unit, FirstDayOfWeek(), loc)
+}
+
+// EndOf returns the last nanosecond of the unit that contains nano in loc.
+func EndOf(nano int64, unit CalendarUnit, loc *Location) int64 {
+	return endOf(nano, unit, FirstDayOfWeek(), loc)
+}
+
+// StartOfDay returns local midnight of the day that contains nano (active timezone).
+func StartOfDay(nano int64) int64 { return startOf(nano, UnitDay, Monday, Local) }
+
+// EndOfDay returns the last nanosecond of the local day that contains nano.
+func EndOfDay(nano int64) int64 { return endOf(nano, UnitDay, Monday, Local) }
+
+// StartOfWeek returns local midnight of the first day of the week that contains nano.
+func StartOfWeek(nano int64, first DayOfWeek) int64 { return startOf(nano, UnitWeek, first, Local) }
+
+// EndOfWeek returns the last nanosecond of the local week that contains nano.
+func EndOfWeek(nano int64, first DayOfWeek) int64 { return endOf(nano, UnitWeek, first, Local) }
+
+// StartOfMonth returns local midnight of the first day of the month that contains nano.
+func StartOfMonth(nano int64) int64 { return startOf(nano, UnitMonth, Monday, Local) }
+
+// EndOfMonth returns the last nanosecond of the local month that contains nano.
+func EndOfMonth(nano int64) int64 { return endOf(nano, UnitMonth, Monday, Local) }
+
+// StartOfQuarter returns local midnight of the first day of the quarter that contains nano.
+func StartOfQuarter(nano int64) int64 { return startOf(nano, UnitQuarter, Monday, Local) }
+
+// EndOfQuarter returns the last nanosecond of the local quarter that contains nano.
+func EndOfQuarter(nano int64) int64 { return endOf(nano, UnitQuarter, Monday, Local) }
+
+// StartOfYear returns local midnight of January 1st of the year that contains nano.
+func StartOfYear(nano int64) int64 { return startOf(nano, UnitYear, Monday, Local) }
+
+// EndOfYear returns the last nanosecond of the local year that contains nano.
+func EndOfYear(nano int64) int64 { return endOf(nano, UnitYear, Monday, Local) }
+
+// StartOf returns the first instant of the unit that contains t, in t's location.
+func (t Time) StartOf(unit CalendarUnit) Time {
+	t.nano = StartOf(t.nano, unit, t.loc)
+	return t
+}
+
+// EndOf returns the last nanosecond of the unit that contains t, in t's location.
+func (t Time) EndOf(unit CalendarUnit) Time {
+	t.nano = EndOf(t.nano, unit, t.loc)
+	return t
+}
+
+// unitStart returns the wall-clock start of the unit containing dt.
+func unitStart(dt DateTime, unit CalendarUnit, first DayOfWeek) DateTime {
+	d := dt.Date
+	switch unit {
+	case UnitHour:
+		return DateTime{Date: d, Time: TimeOfDay{Hour: dt.Time.Hour}}
+	case UnitWeek:
+		d = d.AddDays(-int(floorMod(int64(d.Weekday()-first), 7)))
+	case UnitMonth:
+		d.Day = 1
+	case UnitQuarter:
+		d.Month, d.Day = (d.Month-1)/3*3+1, 1
+	case UnitYear:
+		d.Month, d.Day = January, 1
+	}
+	return DateTime{Date: d}
+}
+
+// unitNext returns the wall-clock start of the unit following start.
+func unitNext(start DateTime, unit CalendarUnit) DateTime {
+	switch unit {
+	case UnitDay:
+		return start.AddDays(1)
+	case UnitWeek:
+		return start.AddDays(7)
+	case UnitMonth:
+		return start.AddMonths(1)
+	case UnitQuarter:
+		return start.AddMonths(3)
+	}
+	return start.AddYears(1)
+}
+
+func startOf(nano int64, unit CalendarUnit, first DayOfWeek, loc *Location) int64 {
+	start := unitStart(DateTimeOf(nano, loc), unit, first)
+	// In an overlap the same wall time happens twice: take the latest one
+	// that is not after nano so the result stays inside the same unit.
+	n, _ := start.UnixNanoIn(loc, Later)
+	if n > nano {
+		n, _ = start.UnixNanoIn(loc, Earlier)
+	}
+	return n
+}
+
+func endOf(nano int64, unit CalendarUnit, first DayOfWeek, loc *Location) int64 {
+	if unit == UnitHour {
+		// elapsed hours, so a repeated hour in an overlap ends at the transition
+		return startOf(nano, unit, first, loc) + secondsPerHour*nanosPerSecond - 1
+	}
+	start := unitStart(DateTimeOf(nano, loc), unit, first)
+	next, _ := unitNext(start, unit).UnixNanoIn(loc, Compatible)
+	return next - 1
+}
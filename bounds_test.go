@@ -0,0 +1,79 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test StartOf / EndOf helpers
+func BoundsShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+
+	local := func(s string) int64 {
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dt.UnixNanoOffset(-3 * 3600)
+	}
+	nano := local("2024-05-15 10:20:30") // Wednesday
+
+	cases := []struct {
+		name      string
+		got, want int64
+	}{
+		{"StartOfDay", time.StartOfDay(nano), local("2024-05-15 00:00")},
+		{"EndOfDay", time.EndOfDay(nano), local("2024-05-16 00:00") - 1},
+		{"StartOfWeek(Monday)", time.StartOfWeek(nano, time.Monday), local("2024-05-13 00:00")},
+		{"StartOfWeek(Sunday)", time.StartOfWeek(nano, time.Sunday), local("2024-05-12 00:00")},
+		{"EndOfWeek(Monday)", time.EndOfWeek(nano, time.Monday), local("2024-05-20 00:00") - 1},
+		{"StartOfMonth", time.StartOfMonth(nano), local("2024-05-01 00:00")},
+		{"EndOfMonth", time.EndOfMonth(nano), local("2024-06-01 00:00") - 1},
+		{"StartOfQuarter", time.StartOfQuarter(nano), local("2024-04-01 00:00")},
+		{"EndOfQuarter", time.EndOfQuarter(nano), local("2024-07-01 00:00") - 1},
+		{"StartOfYear", time.StartOfYear(nano), local("2024-01-01 00:00")},
+		{"EndOfYear", time.EndOfYear(nano), local("2025-01-01 00:00") - 1},
+		{"StartOf(hour)", time.StartOf(nano, time.UnitHour, time.Local), local("2024-05-15 10:00")},
+		{"StartOf(week, UTC)", time.StartOf(nano, time.UnitWeek, time.UTC), local("2024-05-12 21:00")},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %s; want %s", c.name, time.TimeOf(c.got).String(), time.TimeOf(c.want).String())
+		}
+	}
+
+	// Santiago skips 2024-09-08 00:00 → 01:00: the day starts at 01:00
+	scl, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const s = int64(1000000000)
+	noon := time.TimeOf(1725807600 * s).In(scl) // 2024-09-08 12:00 -03
+	if got := noon.StartOf(time.UnitDay).Format("2006-01-02 15:04 -07:00"); got != "2024-09-08 01:00 -03:00" {
+		t.Errorf("StartOf(day) across DST gap = %s", got)
+	}
+	prev := noon.AddDate(0, 0, -1, time.Clamp)
+	if prev.EndOf(time.UnitDay).Add(1).UnixNano() != noon.StartOf(time.UnitDay).UnixNano() {
+		t.Error("EndOf(day)+1 should equal the next StartOf(day)")
+	}
+
+	// New York repeats 01:00-02:00 on 2024-11-03
+	ny, _ := time.LoadLocation("America/New_York")
+	first := time.TimeOf(1730611800 * s).In(ny)  // 01:30 EDT
+	second := time.TimeOf(1730615400 * s).In(ny) // 01:30 EST
+	if got := first.StartOf(time.UnitHour).Format("15:04 -07:00"); got != "01:00 -04:00" {
+		t.Errorf("StartOf(hour) first 01:30 = %s", got)
+	}
+	if got := second.StartOf(time.UnitHour).Format("15:04 -07:00"); got != "01:00 -05:00" {
+		t.Errorf("StartOf(hour) second 01:30 = %s", got)
+	}
+	if first.EndOf(time.UnitHour).Add(1).UnixNano() != second.StartOf(time.UnitHour).UnixNano() {
+		t.Error("first repeated hour should end where the second begins")
+	}
+	if got := second.StartOf(time.UnitDay).Format("2006-01-02 15:04 -07:00"); got != "2024-11-03 00:00 -04:00" {
+		t.Errorf("StartOf(day) on fall-back day = %s", got)
+	}
+}
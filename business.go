@@ -0,0 +1,125 @@
+package time
+
+// HolidayCalendar reports whether a civil date is a non-working holiday.
+// HolidayList and the rule engine (HolidayRules) implement it.
+type HolidayCalendar interface {
+	IsHoliday(d Date) bool
+}
+
+// HolidayList is a hand-maintained set of holidays mapped to their names.
+type HolidayList map[Date]string
+
+// IsHoliday reports whether d is in the list.
+func (h HolidayList) IsHoliday(d Date) bool {
+	_, ok := h[d]
+	return ok
+}
+
+// maxNonBusinessRun bounds searches when every day is excluded.
+const maxNonBusinessRun = 3660
+
+// BusinessCalendar defines working days: every day that is neither a
+// weekend day nor a holiday. The zero value uses the active weekend
+// (SetWeekend), no holidays and the Local location.
+type BusinessCalendar struct {
+	Holidays  HolidayCalendar // nil means no holidays
+	Weekend   DaySet          // zero means the active weekend
+	NoWeekend bool            // every day of the week works (seven-day operations); Weekend is ignored
+	Location  *Location       // nil means Local
+}
+
+func (bc BusinessCalendar) weekend() DaySet {
+	if bc.NoWeekend {
+		return 0
+	}
+	if bc.Weekend == 0 {
+		return Weekend()
+	}
+	return bc.Weekend
+}
+
+// IsBusinessDate reports whether the civil date d is a working day.
+func (bc BusinessCalendar) IsBusinessDate(d Date) bool {
+	if bc.weekend().Contains(d.Weekday()) {
+		return false
+	}
+	return bc.Holidays == nil || !bc.Holidays.IsHoliday(d)
+}
+
+// IsBusinessDay reports whether the local day containing nano is a working day.
+// The local day is derived with the same Weekday / MidnightUTC arithmetic on
+// local seconds, so the backend and WASM always agree.
+func (bc BusinessCalendar) IsBusinessDay(nano int64) bool {
+	sec, _ := splitNano(nano)
+	local := sec + bc.Location.offsetAt(sec)
+	if bc.weekend().Contains(DayOfWeek(Weekday(local))) {
+		return false
+	}
+	return bc.Holidays == nil || !bc.Holidays.IsHoliday(dateFromDays(MidnightUTC(local)/secondsPerDay))
+}
+
+// AddBusinessDays moves nano by n working days (backwards if n is negative),
+// keeping the local time of day. The starting day itself is not counted, so
+// Friday + 1 is Monday and n == 0 returns nano unchanged. It returns false
+// when no working day exists within ten years, e.g. every day is a holiday.
+func (bc BusinessCalendar) AddBusinessDays(nano int64, n int) (int64, bool) {
+	if n == 0 {
+		return nano, true
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	dt := DateTimeOf(nano, bc.Location)
+	d, ok := dt.Date, true
+	for ; n > 0 && ok; n-- {
+		d, ok = bc.nextBusinessDate(d, step)
+	}
+	if !ok {
+		return 0, false
+	}
+	dt.Date = d
+	out, _ := dt.UnixNanoIn(bc.Location, Compatible)
+	return out, true
+}
+
+// NextBusinessDay returns the same local time of day on the first working
+// day after nano's day, or false if there is none (see AddBusinessDays).
+func (bc BusinessCalendar) NextBusinessDay(nano int64) (int64, bool) {
+	return bc.AddBusinessDays(nano, 1)
+}
+
+// PrevBusinessDay returns the same local time of day on the last working
+// day before nano's day, or false if there is none (see AddBusinessDays).
+func (bc BusinessCalendar) PrevBusinessDay(nano int64) (int64, bool) {
+	return bc.AddBusinessDays(nano, -1)
+}
+
+// BusinessDaysBetween counts the working days after nano1's local day up to
+// and including nano2's local day, so AddBusinessDays(nano1, k) lands on
+// nano2 for a working day. Negative if nano2 is before nano1.
+func (bc BusinessCalendar) BusinessDaysBetween(nano1, nano2 int64) int {
+	from := DateTimeOf(nano1, bc.Location).Date
+	to := DateTimeOf(nano2, bc.Location).Date
+	if to.Before(from) {
+		return -bc.BusinessDaysBetween(nano2, nano1)
+	}
+	count := 0
+	for d := from.AddDays(1); !d.After(to); d = d.AddDays(1) {
+		if bc.IsBusinessDate(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// nextBusinessDate returns the first working day after d in the direction of step.
+func (bc BusinessCalendar) nextBusinessDate(d Date, step int) (Date, bool) {
+	for i := 0; i < maxNonBusinessRun; i++ {
+		d = d.AddDays(step)
+		if bc.IsBusinessDate(d) {
+			return d, true
+		}
+	}
+	return d, false
+}
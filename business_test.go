@@ -0,0 +1,101 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test business-day arithmetic
+func BusinessShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+
+	at := func(s string) int64 {
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dt.UnixNanoOffset(-3 * 3600)
+	}
+	bc := time.BusinessCalendar{
+		Holidays: time.HolidayList{
+			{Year: 2024, Month: time.May, Day: 1}:  "Labour Day",
+			{Year: 2024, Month: time.May, Day: 21}: "Navy Day",
+		},
+	}
+
+	// Thursday 2024-04-25 22:30 local is already Friday 01:30 UTC
+	thu := at("2024-04-25 22:30")
+	if !bc.IsBusinessDay(thu) {
+		t.Error("Thursday should be a business day")
+	}
+	if bc.IsBusinessDay(at("2024-04-27 10:00")) || bc.IsBusinessDay(at("2024-05-01 10:00")) {
+		t.Error("Saturday and holidays should not be business days")
+	}
+
+	cases := []struct {
+		n    int
+		want string
+	}{
+		{1, "2024-04-26 22:30"},
+		{2, "2024-04-29 22:30"},
+		{4, "2024-05-02 22:30"}, // skips weekend and May 1
+		{-4, "2024-04-19 22:30"},
+		{0, "2024-04-25 22:30"},
+	}
+	for _, c := range cases {
+		got, ok := bc.AddBusinessDays(thu, c.n)
+		if !ok || time.FormatDateTimeShort(got) != c.want {
+			t.Errorf("AddBusinessDays(%d) = %s; want %s", c.n, time.FormatDateTimeShort(got), c.want)
+		}
+	}
+	if got, _ := bc.NextBusinessDay(at("2024-04-30 09:00")); time.FormatDateTimeShort(got) != "2024-05-02 09:00" {
+		t.Errorf("NextBusinessDay = %s", time.FormatDateTimeShort(got))
+	}
+	if got, _ := bc.PrevBusinessDay(at("2024-04-29 09:00")); time.FormatDateTimeShort(got) != "2024-04-26 09:00" {
+		t.Errorf("PrevBusinessDay = %s", time.FormatDateTimeShort(got))
+	}
+
+	// May 2024 has 23 weekdays minus 2 holidays
+	if got := bc.BusinessDaysBetween(at("2024-04-30 18:00"), at("2024-05-31 08:00")); got != 21 {
+		t.Errorf("BusinessDaysBetween(May) = %d; want 21", got)
+	}
+	if got := bc.BusinessDaysBetween(at("2024-05-31 08:00"), at("2024-04-30 18:00")); got != -21 {
+		t.Errorf("BusinessDaysBetween(reversed) = %d; want -21", got)
+	}
+	if got, _ := bc.AddBusinessDays(at("2024-04-30 18:00"), 21); time.FormatDate(got) != "2024-05-31" {
+		t.Errorf("AddBusinessDays(21) = %s; want 2024-05-31", time.FormatDate(got))
+	}
+
+	// Custom weekend (Friday-Saturday) and explicit location
+	me := time.BusinessCalendar{Weekend: time.NewDaySet(time.Friday, time.Saturday), Location: time.UTC}
+	if got, _ := me.NextBusinessDay(at("2024-04-25 12:00")); time.FormatISO8601(got) != "2024-04-28T15:00:00Z" {
+		t.Errorf("NextBusinessDay(Fri-Sat weekend) = %s", time.FormatISO8601(got))
+	}
+
+	// Seven-day operation: NoWeekend keeps Saturday and Sunday as working days
+	ops := time.BusinessCalendar{Weekend: time.NewDaySet(time.Saturday), NoWeekend: true, Location: time.UTC}
+	if !ops.IsBusinessDate(time.Date{Year: 2024, Month: time.April, Day: 27}) || !ops.IsBusinessDay(at("2024-04-28 12:00")) {
+		t.Error("NoWeekend should make weekends working days")
+	}
+	if got, _ := ops.AddBusinessDays(at("2024-04-26 12:00"), 2); time.FormatISO8601(got) != "2024-04-28T15:00:00Z" {
+		t.Errorf("AddBusinessDays(NoWeekend) = %s", time.FormatISO8601(got))
+	}
+	if got := ops.BusinessDaysBetween(at("2024-04-26 12:00"), at("2024-05-03 12:00")); got != 7 {
+		t.Errorf("BusinessDaysBetween(NoWeekend) = %d; want 7", got)
+	}
+
+	// No working day at all: the result says so instead of returning the input
+	closed := time.BusinessCalendar{Weekend: time.NewDaySet(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)}
+	if got, ok := closed.AddBusinessDays(thu, 1); ok || got != 0 {
+		t.Errorf("AddBusinessDays(no working days) = %d, %v; want 0, false", got, ok)
+	}
+	if _, ok := closed.PrevBusinessDay(thu); ok {
+		t.Error("PrevBusinessDay(no working days) should report false")
+	}
+	if got, ok := closed.AddBusinessDays(thu, 0); !ok || got != thu {
+		t.Error("AddBusinessDays(0) should return the input")
+	}
+}
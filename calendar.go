@@ -0,0 +1,57 @@
+package time
+
+// IsLeapYear reports whether year is a leap year in the Gregorian calendar.
+func IsLeapYear(year int) bool {
+	return isLeapYear(year)
+}
+
+// DaysInMonth returns the number of days of month in year (28-31).
+func DaysInMonth(year int, month Month) int {
+	return daysInMonth(year, int(month))
+}
+
+// DaysInYear returns 366 for leap years and 365 otherwise.
+func DaysInYear(year int) int {
+	if isLeapYear(year) {
+		return 366
+	}
+	return 365
+}
+
+// YearDay returns the day of the year (1-366) of a UnixNano timestamp in the active timezone offset.
+func YearDay(nano int64) int {
+	return DateOf(nano).YearDay()
+}
+
+// Quarter returns the quarter of the year (1-4) of a UnixNano timestamp in the active timezone offset.
+func Quarter(nano int64) int {
+	return DateOf(nano).Quarter()
+}
+
+// Quarter returns the quarter of the year (1-4) that contains m.
+func (m Month) Quarter() int {
+	return (int(m)-1)/3 + 1
+}
+
+// Quarter returns the quarter of the year (1-4) that contains d.
+func (d Date) Quarter() int {
+	return d.Month.Quarter()
+}
+
+// MonthGrid returns the 6x7 cell layout of a month view for calendar
+// widgets: rows are weeks starting on first, and cells before and after
+// the month are filled with days of the adjacent months (compare
+// cell.Month with month to dim them). Six rows fit every month.
+func MonthGrid(year int, month Month, first DayOfWeek) [6][7]Date {
+	start := Date{Year: year, Month: month, Day: 1}
+	start = start.AddDays(-int(floorMod(int64(start.Weekday()-first), 7)))
+	days := start.days()
+	var grid [6][7]Date
+	for row := range grid {
+		for col := range grid[row] {
+			grid[row][col] = dateFromDays(days)
+			days++
+		}
+	}
+	return grid
+}
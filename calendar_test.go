@@ -0,0 +1,52 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test calendar introspection helpers
+func CalendarShared(t *testing.T) {
+	for year, want := range map[int]bool{1900: false, 2000: true, 2023: false, 2024: true} {
+		if time.IsLeapYear(year) != want {
+			t.Errorf("IsLeapYear(%d) = %v; want %v", year, !want, want)
+		}
+	}
+	if time.DaysInMonth(2024, time.February) != 29 || time.DaysInMonth(2023, time.February) != 28 ||
+		time.DaysInMonth(2024, time.April) != 30 || time.DaysInMonth(2024, time.December) != 31 {
+		t.Error("DaysInMonth failed")
+	}
+	if time.DaysInYear(2024) != 366 || time.DaysInYear(2100) != 365 {
+		t.Error("DaysInYear failed")
+	}
+	if time.March.Quarter() != 1 || time.October.Quarter() != 4 || (time.Date{Year: 2024, Month: 7, Day: 1}).Quarter() != 3 {
+		t.Error("Quarter failed")
+	}
+
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+	// 2024-04-01 01:00 UTC is still March 31 (Q1, day 91) in UTC-3
+	nano := time.Date{Year: 2024, Month: 4, Day: 1}.UnixNanoUTC() + 3600*1000000000
+	if time.YearDay(nano) != 91 || time.Quarter(nano) != 1 {
+		t.Errorf("YearDay/Quarter = %d/%d; want 91/1", time.YearDay(nano), time.Quarter(nano))
+	}
+
+	// February 2026 starts on a Sunday
+	grid := time.MonthGrid(2026, time.February, time.Monday)
+	if grid[0][0].String() != "2026-01-26" || grid[0][6].String() != "2026-02-01" || grid[5][6].String() != "2026-03-08" {
+		t.Errorf("MonthGrid(Monday) corners = %s %s %s", grid[0][0], grid[0][6], grid[5][6])
+	}
+	grid = time.MonthGrid(2026, time.February, time.Sunday)
+	if grid[0][0].String() != "2026-02-01" || grid[4][0].String() != "2026-03-01" {
+		t.Errorf("MonthGrid(Sunday) = %s … %s", grid[0][0], grid[4][0])
+	}
+	for _, row := range grid {
+		for col, cell := range row {
+			if cell.Weekday() != time.Sunday.Add(col) {
+				t.Fatalf("MonthGrid cell %s in column %d", cell, col)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package time
+
+// Pure proleptic Gregorian calendar arithmetic shared by every provider.
+// Nothing here depends on the platform, so results are identical on the
+// backend and in WASM.
+
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 60 * secondsPerMinute
+	secondsPerDay    = 24 * secondsPerHour
+	nanosPerSecond   = 1000000000
+	nanosPerDay      = secondsPerDay * nanosPerSecond
+
+	// UnixNano covers 1677-09-21 00:12:43.145224192 to
+	// 2262-04-11 23:47:16.854775807 UTC.
+	minUnixNano = -1 << 63
+	maxUnixNano = 1<<63 - 1
+)
+
+// unixNanoOf combines seconds since the epoch and a nanosecond part in
+// [0, 1e9) into a UnixNano instant. Outside the int64 range it saturates to
+// minUnixNano or maxUnixNano and reports false.
+func unixNanoOf(sec, nsec int64) (int64, bool) {
+	const maxSec = maxUnixNano / nanosPerSecond
+	const minSec, minNsec = -maxSec - 1, nanosPerSecond - maxUnixNano%nanosPerSecond - 1
+	switch {
+	case sec > maxSec || (sec == maxSec && nsec > maxUnixNano%nanosPerSecond):
+		return maxUnixNano, false
+	case sec < minSec || (sec == minSec && nsec < minNsec):
+		return minUnixNano, false
+	}
+	// Wraps in the intermediate product for minSec, but the sum is exact.
+	return sec*nanosPerSecond + nsec, true
+}
+
+// formatYear formats year with four digits, or outside 0000-9999 in the
+// ISO 8601 expanded form used by JavaScript's toISOString: a sign and at
+// least six digits ("+010000", "-000001").
+func formatYear(year int) string {
+	switch {
+	case year >= 0 && year <= 9999:
+		return string(appendInt(nil, year, 4))
+	case year > 0:
+		return string(appendInt([]byte{'+'}, year, 6))
+	}
+	return string(appendInt(nil, year, 6))
+}
+
+// parseYear parses a four-digit year or an expanded year: a sign followed
+// by four to nine digits.
+func parseYear(s string) (int, bool) {
+	if len(s) == 4 {
+		return parseDigits(s)
+	}
+	if len(s) < 5 || len(s) > 10 {
+		return 0, false
+	}
+	n, ok := parseDigits(s[1:])
+	switch s[0] {
+	case '+':
+		return n, ok
+	case '-':
+		return -n, ok
+	}
+	return 0, false
+}
+
+// floorDiv returns a/b rounded towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the remainder of floorDiv(a, b); it has the sign of b.
+func floorMod(a, b int64) int64 {
+	return a - floorDiv(a, b)*b
+}
+
+// daysFromCivil returns the number of days since 1970-01-01 for the given
+// year, month (1-12) and day. Out-of-range days are not normalised.
+// Algorithm: Howard Hinnant, "chrono-Compatible Low-Level Date Algorithms".
+func daysFromCivil(year, month, day int) int64 {
+	y := int64(year)
+	if month <= 2 {
+		y--
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400
+	m := int64(month)
+	var mp int64
+	if m > 2 {
+		mp = m - 3
+	} else {
+		mp = m + 9
+	}
+	doy := (153*mp+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// civilFromDays is the inverse of daysFromCivil.
+func civilFromDays(days int64) (year, month, day int) {
+	z := days + 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	y := yoe + era*400
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	d := doy - (153*mp+2)/5 + 1
+	var m int64
+	if mp < 10 {
+		m = mp + 3
+	} else {
+		m = mp - 9
+	}
+	if m <= 2 {
+		y++
+	}
+	return int(y), int(m), int(d)
+}
+
+// isLeapYear reports whether year is a leap year in the Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth returns the number of days of month (1-12) in year.
+func daysInMonth(year, month int) int {
+	switch month {
+	case 2:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// weekdayFromDays returns the day of the week (0=Sunday) for a day count
+// since 1970-01-01, which was a Thursday.
+func weekdayFromDays(days int64) int {
+	return int(floorMod(days+4, 7))
+}
+
+// splitNano splits a UnixNano timestamp into whole seconds and the
+// non-negative nanosecond remainder.
+func splitNano(nano int64) (sec, nsec int64) {
+	sec = floorDiv(nano, nanosPerSecond)
+	return sec, nano - sec*nanosPerSecond
+}
+
+// localOffsetSeconds returns the active timezone offset in seconds.
+func localOffsetSeconds() int64 {
+	return int64(getOffsetMinutes()) * secondsPerMinute
+}
@@ -0,0 +1,245 @@
+package time
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Clock supplies the current time and timers. Now, AfterFunc, TickFunc and
+// everything built on them (IsToday, Today, Scheduler, ...) go through the
+// active Clock, so tests can replace it with a FakeClock.
+type Clock interface {
+	Now() int64
+	Monotonic() int64
+	AfterFunc(milliseconds int, f func()) Timer
+	TickFunc(milliseconds int, f func()) Ticker
+}
+
+// SystemClock is the real clock of the active provider.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() int64 { return provider().UnixNano() }
+
+func (systemClock) Monotonic() int64 { return provider().Monotonic() }
+
+func (systemClock) AfterFunc(milliseconds int, f func()) Timer {
+	return provider().AfterFunc(milliseconds, f)
+}
+
+func (systemClock) TickFunc(milliseconds int, f func()) Ticker {
+	return provider().TickFunc(milliseconds, f)
+}
+
+type clockBox struct{ c Clock }
+
+var activeClock atomic.Pointer[clockBox]
+
+func init() {
+	activeClock.Store(&clockBox{SystemClock})
+}
+
+// SetClock replaces the clock used by the package. nil restores SystemClock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = SystemClock
+	}
+	activeClock.Store(&clockBox{c})
+}
+
+// GetClock returns the clock in use.
+func GetClock() Clock {
+	return activeClock.Load().c
+}
+
+// FakeClock is a manually advanced Clock for deterministic tests. Its
+// timers and tickers fire only from Advance or Set, in deadline order,
+// with Now reporting each deadline while its callback runs.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    int64
+	mono   int64 // advances only when the clock moves forward
+	seq    int
+	events []*fakeEvent
+}
+
+type fakeEvent struct {
+	clock  *FakeClock
+	at     int64
+	period int64 // nanoseconds; 0 for one-shot timers
+	seq    int   // orders events with the same deadline by arming order
+	f      func()
+	active bool
+}
+
+// NewFakeClock returns a FakeClock reading the UnixNano instant start.
+func NewFakeClock(start int64) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Monotonic returns the fake monotonic reading. It starts at zero and
+// advances exactly with Advance and forward Set calls; moving the clock
+// backward leaves it unchanged, like a system clock being turned back.
+func (c *FakeClock) Monotonic() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mono
+}
+
+// moveTo sets the clock to nano, advancing the monotonic reading when
+// moving forward; c.mu must be held.
+func (c *FakeClock) moveTo(nano int64) {
+	c.mono += max(nano-c.now, 0)
+	c.now = nano
+}
+
+// Advance moves the clock forward by nanos, firing every timer and tick
+// that falls due on the way.
+func (c *FakeClock) Advance(nanos int64) {
+	c.Set(c.Now() + nanos)
+}
+
+// Set moves the clock to the UnixNano instant nano, firing due timers and
+// ticks when moving forward. Moving backward fires nothing.
+func (c *FakeClock) Set(nano int64) {
+	for {
+		c.mu.Lock()
+		var next *fakeEvent
+		for _, e := range c.events {
+			if e.at <= nano && (next == nil || e.at < next.at || (e.at == next.at && e.seq < next.seq)) {
+				next = e
+			}
+		}
+		if next == nil {
+			c.moveTo(nano)
+			c.mu.Unlock()
+			return
+		}
+		c.moveTo(max(c.now, next.at))
+		if next.period > 0 {
+			next.at += next.period
+			c.seq++
+			next.seq = c.seq
+		} else {
+			c.remove(next)
+		}
+		f := next.f
+		c.mu.Unlock()
+		f()
+	}
+}
+
+// PendingTimers returns the number of armed timers and tickers.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.events)
+}
+
+// AfterFunc arms a one-shot timer on the fake clock.
+func (c *FakeClock) AfterFunc(milliseconds int, f func()) Timer {
+	e := &fakeEvent{clock: c, f: f}
+	c.mu.Lock()
+	c.arm(e, milliseconds, 0)
+	c.mu.Unlock()
+	return fakeTimer{e}
+}
+
+// TickFunc arms a ticker on the fake clock.
+func (c *FakeClock) TickFunc(milliseconds int, f func()) Ticker {
+	e := &fakeEvent{clock: c, f: f}
+	c.mu.Lock()
+	c.arm(e, milliseconds, int64(max(milliseconds, 1))*1_000_000)
+	c.mu.Unlock()
+	return fakeTicker{e}
+}
+
+// arm schedules e; c.mu must be held.
+func (c *FakeClock) arm(e *fakeEvent, milliseconds int, period int64) {
+	if period > 0 {
+		e.at = c.now + period
+	} else {
+		e.at = c.now + int64(max(milliseconds, 0))*1_000_000
+	}
+	e.period = period
+	c.seq++
+	e.seq = c.seq
+	if !e.active {
+		e.active = true
+		c.events = append(c.events, e)
+	}
+}
+
+// remove disarms e; c.mu must be held.
+func (c *FakeClock) remove(e *fakeEvent) bool {
+	if !e.active {
+		return false
+	}
+	e.active = false
+	for i, o := range c.events {
+		if o == e {
+			c.events = append(c.events[:i], c.events[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+type fakeTimer struct{ e *fakeEvent }
+
+func (t fakeTimer) Stop() bool {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(t.e)
+}
+
+func (t fakeTimer) Reset(milliseconds int) bool {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	was := t.e.active
+	c.arm(t.e, milliseconds, 0)
+	return was
+}
+
+func (t fakeTimer) Pending() bool {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return t.e.active
+}
+
+func (t fakeTimer) Remaining() int {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !t.e.active {
+		return 0
+	}
+	return int((t.e.at - c.now + 999_999) / 1_000_000)
+}
+
+type fakeTicker struct{ e *fakeEvent }
+
+func (t fakeTicker) Stop() bool {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(t.e)
+}
+
+func (t fakeTicker) Reset(milliseconds int) {
+	c := t.e.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.arm(t.e, milliseconds, int64(max(milliseconds, 1))*1_000_000)
+}
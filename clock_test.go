@@ -0,0 +1,108 @@
+package time_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test the fake clock and the package functions that depend on the clock
+func ClockShared(t *testing.T) {
+	start := int64(1705361400000000000) // 2024-01-15 23:30:00 UTC
+	clock := time.NewFakeClock(start)
+	time.SetClock(clock)
+	defer time.SetClock(nil)
+
+	if time.Now() != start || time.GetClock() != time.Clock(clock) {
+		t.Fatalf("Now = %d; want %d", time.Now(), start)
+	}
+	if time.Today().String() != "2024-01-15" || !time.IsToday(start-23*3600e9) || time.IsToday(start+3600e9) {
+		t.Error("IsToday should follow the fake clock")
+	}
+	if !time.IsPast(start-1) || time.IsPast(start) || !time.IsFuture(start+1) || time.IsFuture(start) {
+		t.Error("IsPast/IsFuture should follow the fake clock")
+	}
+
+	// Timers fire in deadline order, seeing their deadline as Now.
+	var log []string
+	record := func(name string) func() {
+		return func() { log = append(log, name+"@"+time.TimeOf(time.Now()).UTC().Format("15:04:05")) }
+	}
+	time.AfterFunc(3000, record("c"))
+	time.AfterFunc(1000, record("a"))
+	b := time.AfterFunc(2000, record("b"))
+	stopped := time.AfterFunc(1500, record("x"))
+	time.AfterFunc(1000, func() {
+		record("a2")()
+		time.AfterFunc(500, record("nested")) // armed from a callback, due within the same Advance
+	})
+	if !stopped.Stop() {
+		t.Error("Stop should return true")
+	}
+	if b.Remaining() != 2000 || !b.Pending() {
+		t.Errorf("Remaining = %d", b.Remaining())
+	}
+	clock.Advance(2500e6)
+	want := "a@23:30:01 a2@23:30:01 nested@23:30:01 b@23:30:02"
+	if got := strings.Join(log, " "); got != want {
+		t.Errorf("fired %s; want %s", got, want)
+	}
+	if clock.Now() != start+2500e6 || clock.PendingTimers() != 1 || b.Pending() {
+		t.Errorf("after Advance: now %d, pending %d", clock.Now()-start, clock.PendingTimers())
+	}
+	if b.Reset(100) {
+		t.Error("Reset of a fired timer should return false")
+	}
+	clock.Advance(1000e6)
+	if got := strings.Join(log, " "); got != want+" b@23:30:02 c@23:30:03" {
+		t.Errorf("fired %s", got)
+	}
+
+	// Tickers
+	ticks := 0
+	ticker := time.TickFunc(250, func() { ticks++ })
+	clock.Advance(1000e6)
+	if ticks != 4 {
+		t.Errorf("ticks = %d; want 4", ticks)
+	}
+	ticker.Reset(500)
+	clock.Advance(1000e6)
+	if ticks != 6 {
+		t.Errorf("ticks after Reset = %d; want 6", ticks)
+	}
+	if !ticker.Stop() || ticker.Stop() {
+		t.Error("Stop should return true once")
+	}
+	clock.Advance(1000e6)
+	if ticks != 6 || clock.PendingTimers() != 0 {
+		t.Errorf("ticks = %d, pending = %d after Stop", ticks, clock.PendingTimers())
+	}
+
+	// Compensated ticker and scheduler run on the fake clock too.
+	ticks = 0
+	comp := time.TickFuncCompensated(100, func() { ticks++ })
+	clock.Advance(1000e6)
+	comp.Stop()
+	if ticks != 10 {
+		t.Errorf("compensated ticks = %d; want 10", ticks)
+	}
+
+	var runs []string
+	cron, err := time.ParseCron("0 0 * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sch := time.NewScheduler()
+	defer sch.Stop()
+	err = sch.Add(time.Job{Name: "nightly", Schedule: cron, Run: func() {
+		runs = append(runs, time.TimeOf(time.Now()).UTC().Format("01-02 15:04:05"))
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(48 * 3600e9)
+	if got := strings.Join(runs, " "); got != "01-16 00:00:00 01-17 00:00:00" {
+		t.Errorf("scheduler runs = %s", got)
+	}
+}
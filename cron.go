@@ -0,0 +1,408 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Cron is a parsed cron expression evaluated in a Location.
+//
+// Fields are "minute hour day-of-month month day-of-week", optionally
+// preceded by a seconds field. Each field accepts *, lists, ranges and
+// steps ("1-5", "*/15", "10-40/10"); months and days also accept English
+// names (JAN, MON). Day of month supports L (last day), L-n, LW (last
+// weekday) and nW (nearest weekday to n); day of week supports nL (last n
+// of the month) and n#k (k-th n of the month). When both day fields are
+// restricted a day matches either of them, as in Vixie cron.
+type Cron struct {
+	spec    string
+	loc     *Location
+	seconds uint64
+	minutes uint64
+	hours   uint64
+	dom     uint64 // bits 1-31
+	months  uint64 // bits 1-12
+	dow     uint64 // bits 0-6, Sunday = 0
+	domStar bool   // field starts with * or ?, as in Vixie cron
+	dowStar bool
+	lastDay []int // L-n offsets
+	nearest []int // nW days; 0 means LW
+	dowLast uint64
+	dowNth  [7]uint64 // bits 1-5 per weekday
+}
+
+// cronMacros are the supported @ shortcuts.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonths = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronDays   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// cronSearchYears bounds Next and Prev: the Gregorian calendar repeats
+// every 400 years, so a spec without a match in that span never matches.
+const cronSearchYears = 400
+
+// ParseCron parses a 5- or 6-field cron expression or an @macro (@yearly,
+// @monthly, @weekly, @daily, @hourly). A "CRON_TZ=Area/City " or
+// "TZ=Area/City " prefix overrides loc; nil means Local.
+func ParseCron(spec string, loc *Location) (*Cron, error) {
+	c := &Cron{spec: spec, loc: loc}
+	expr := TrimSpace(spec)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if len(expr) > len(prefix) && expr[:len(prefix)] == prefix {
+			name, rest, _ := cutByte(expr[len(prefix):], ' ')
+			tz, err := LoadLocation(name)
+			if err != nil {
+				return nil, err
+			}
+			c.loc, expr = tz, TrimSpace(rest)
+		}
+	}
+	if macro, ok := cronMacros[ToLower(expr)]; ok {
+		expr = macro
+	}
+	var fields []string
+	for _, f := range Convert(expr).Split(" ") {
+		if f = TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, Errf("invalid cron expression: %s (want 5 or 6 fields)", spec)
+	}
+
+	var err error
+	if c.seconds, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.minutes, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if err = c.parseDayOfMonth(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[4], 1, 12, cronMonths); err != nil {
+		return nil, err
+	}
+	if err = c.parseDayOfWeek(fields[5]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// String returns the expression as given to ParseCron.
+func (c *Cron) String() string { return c.spec }
+
+// Location returns the location the expression is evaluated in.
+func (c *Cron) Location() *Location { return c.loc }
+
+func (c *Cron) parseDayOfMonth(field string) error {
+	c.domStar = cronStar(field)
+	for _, part := range Convert(field).Split(",") {
+		switch {
+		case part == "L":
+			c.lastDay = append(c.lastDay, 0)
+		case len(part) > 2 && part[:2] == "L-":
+			n, ok := parseDigits(part[2:])
+			if !ok || n > 30 {
+				return Errf("invalid cron day of month: %s", part)
+			}
+			c.lastDay = append(c.lastDay, n)
+		case part == "LW":
+			c.nearest = append(c.nearest, 0)
+		case len(part) > 1 && part[len(part)-1] == 'W':
+			n, ok := parseDigits(part[:len(part)-1])
+			if !ok || n < 1 || n > 31 {
+				return Errf("invalid cron day of month: %s", part)
+			}
+			c.nearest = append(c.nearest, n)
+		default:
+			bits, err := parseCronField(part, 1, 31, nil)
+			if err != nil {
+				return err
+			}
+			c.dom |= bits
+		}
+	}
+	return nil
+}
+
+func (c *Cron) parseDayOfWeek(field string) error {
+	c.dowStar = cronStar(field)
+	for _, part := range Convert(field).Split(",") {
+		if day, nth, ok := cutByte(part, '#'); ok {
+			d, err := parseCronValue(day, 0, 7, cronDays)
+			k, okK := parseDigits(nth)
+			if err != nil || !okK || k < 1 || k > 5 {
+				return Errf("invalid cron day of week: %s", part)
+			}
+			c.dowNth[d%7] |= 1 << k
+			continue
+		}
+		if len(part) > 1 && part[len(part)-1] == 'L' {
+			d, err := parseCronValue(part[:len(part)-1], 0, 7, cronDays)
+			if err != nil {
+				return Errf("invalid cron day of week: %s", part)
+			}
+			c.dowLast |= 1 << (d % 7)
+			continue
+		}
+		bits, err := parseCronField(part, 0, 7, cronDays)
+		if err != nil {
+			return err
+		}
+		if bits&(1<<7) != 0 { // 7 is also Sunday
+			bits = bits&^(1<<7) | 1
+		}
+		c.dow |= bits
+	}
+	return nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps
+// into a bit set.
+func parseCronField(field string, lo, hi int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range Convert(field).Split(",") {
+		rng, stepStr, hasStep := cutByte(part, '/')
+		step := 1
+		if hasStep {
+			var ok bool
+			if step, ok = parseDigits(stepStr); !ok || step < 1 {
+				return 0, Errf("invalid cron step: %s", part)
+			}
+		}
+		from, to := lo, hi
+		if rng != "*" && rng != "?" {
+			a, b, isRange := cutByte(rng, '-')
+			var err error
+			if from, err = parseCronValue(a, lo, hi, names); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = parseCronValue(b, lo, hi, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				to = hi
+			}
+			if to < from {
+				return 0, Errf("invalid cron range: %s", part)
+			}
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a number in [lo, hi] or a three-letter name.
+func parseCronValue(s string, lo, hi int, names []string) (int, error) {
+	if n, ok := parseDigits(s); ok && s != "" {
+		if n < lo || n > hi {
+			return 0, Errf("cron value out of range %d-%d: %s", lo, hi, s)
+		}
+		return n, nil
+	}
+	up := ToUpper(s)
+	for i, name := range names {
+		if up == name {
+			if lo == 1 {
+				return i + 1, nil
+			}
+			return i, nil
+		}
+	}
+	return 0, Errf("invalid cron value: %s", s)
+}
+
+// matchDate reports whether the day fields and month match d.
+func (c *Cron) matchDate(d Date) bool {
+	if c.months&(1<<int(d.Month)) == 0 {
+		return false
+	}
+	dim := daysInMonth(d.Year, int(d.Month))
+	domMatch := c.dom&(1<<d.Day) != 0
+	for _, off := range c.lastDay {
+		domMatch = domMatch || d.Day == dim-off
+	}
+	for _, n := range c.nearest {
+		domMatch = domMatch || d.Day == nearestWeekday(d.Year, d.Month, n, dim)
+	}
+
+	wd := d.Weekday()
+	dowMatch := c.dow&(1<<int(wd)) != 0 ||
+		(c.dowLast&(1<<int(wd)) != 0 && d.Day+7 > dim) ||
+		c.dowNth[wd]&(1<<((d.Day-1)/7+1)) != 0
+
+	// Vixie cron: a day field starting with * (such as */2) does not
+	// trigger the OR rule, so both fields must match.
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// cronStar reports whether a day field counts as unrestricted for the
+// day-of-month / day-of-week OR rule.
+func cronStar(field string) bool {
+	return field != "" && (field[0] == '*' || field[0] == '?')
+}
+
+// nearestWeekday returns the Monday-Friday day closest to day n of the
+// month without leaving it (n = 0 means the last weekday), or 0 when the
+// month has no day n.
+func nearestWeekday(year int, month Month, n, dim int) int {
+	if n == 0 {
+		n = dim
+	}
+	if n > dim {
+		return 0
+	}
+	switch (Date{Year: year, Month: month, Day: n}).Weekday() {
+	case Saturday:
+		if n == 1 {
+			return 3
+		}
+		return n - 1
+	case Sunday:
+		if n == dim {
+			return n - 2
+		}
+		return n + 1
+	}
+	return n
+}
+
+// nextTime returns the first matching second of the day at or after sec.
+func (c *Cron) nextTime(sec int) (int, bool) {
+	h0, m0, s0 := sec/secondsPerHour, sec/secondsPerMinute%60, sec%60
+	for h := h0; h < 24; h++ {
+		if c.hours&(1<<h) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if (h == h0 && m < m0) || c.minutes&(1<<m) == 0 {
+				continue
+			}
+			for s := 0; s < 60; s++ {
+				if (h == h0 && m == m0 && s < s0) || c.seconds&(1<<s) == 0 {
+					continue
+				}
+				return h*secondsPerHour + m*secondsPerMinute + s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// prevTime returns the last matching second of the day at or before sec.
+func (c *Cron) prevTime(sec int) (int, bool) {
+	h0, m0, s0 := sec/secondsPerHour, sec/secondsPerMinute%60, sec%60
+	for h := h0; h >= 0; h-- {
+		if c.hours&(1<<h) == 0 {
+			continue
+		}
+		for m := 59; m >= 0; m-- {
+			if (h == h0 && m > m0) || c.minutes&(1<<m) == 0 {
+				continue
+			}
+			for s := 59; s >= 0; s-- {
+				if (h == h0 && m == m0 && s > s0) || c.seconds&(1<<s) == 0 {
+					continue
+				}
+				return h*secondsPerHour + m*secondsPerMinute + s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Next returns the first matching instant strictly after the UnixNano
+// instant after. Wall times skipped by a DST change run at the shifted
+// instant; wall times repeated by a DST change run once.
+func (c *Cron) Next(after int64) (int64, bool) {
+	sec, _ := splitNano(after)
+	local := sec + c.loc.offsetAt(sec) + 1
+	day := floorDiv(local, secondsPerDay)
+	from := int(local - day*secondsPerDay)
+	d := dateFromDays(day)
+	limit := d.Year + cronSearchYears
+	for d.Year <= limit {
+		if c.months&(1<<int(d.Month)) == 0 {
+			d = Date{Year: d.Year, Month: d.Month, Day: 1}.AddMonths(1)
+			from = 0
+			continue
+		}
+		if c.matchDate(d) {
+			for {
+				t, ok := c.nextTime(from)
+				if !ok {
+					break
+				}
+				wall := DateTime{Date: d, Time: TimeOfDay{Hour: t / secondsPerHour, Minute: t / secondsPerMinute % 60, Second: t % 60}}
+				nano, err := wall.UnixNanoIn(c.loc, Compatible)
+				if err == nil && nano > after {
+					return nano, true
+				}
+				from = t + 1
+			}
+		}
+		d, from = d.AddDays(1), 0
+	}
+	return 0, false
+}
+
+// Prev returns the last matching instant strictly before the UnixNano
+// instant before.
+func (c *Cron) Prev(before int64) (int64, bool) {
+	sec, nsec := splitNano(before)
+	local := sec + c.loc.offsetAt(sec)
+	if nsec == 0 {
+		local--
+	}
+	day := floorDiv(local, secondsPerDay)
+	upto := int(local - day*secondsPerDay)
+	d := dateFromDays(day)
+	limit := d.Year - cronSearchYears
+	for d.Year >= limit {
+		if c.months&(1<<int(d.Month)) == 0 {
+			d = Date{Year: d.Year, Month: d.Month, Day: 1}.AddDays(-1)
+			upto = secondsPerDay - 1
+			continue
+		}
+		if c.matchDate(d) {
+			for upto >= 0 {
+				t, ok := c.prevTime(upto)
+				if !ok {
+					break
+				}
+				wall := DateTime{Date: d, Time: TimeOfDay{Hour: t / secondsPerHour, Minute: t / secondsPerMinute % 60, Second: t % 60}}
+				nano, err := wall.UnixNanoIn(c.loc, Compatible)
+				if err == nil && nano < before {
+					return nano, true
+				}
+				upto = t - 1
+			}
+		}
+		d, upto = d.AddDays(-1), secondsPerDay-1
+	}
+	return 0, false
+}
@@ -0,0 +1,118 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test cron parsing and next/previous run calculation
+func CronShared(t *testing.T) {
+	at := func(s string, loc *time.Location) int64 {
+		t.Helper()
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nano, err := dt.UnixNanoIn(loc, time.Compatible)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nano
+	}
+	runs := func(spec string, loc *time.Location, from string, n int) []string {
+		t.Helper()
+		c, err := time.ParseCron(spec, loc)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", spec, err)
+		}
+		var out []string
+		nano := at(from, c.Location())
+		for range n {
+			next, ok := c.Next(nano)
+			if !ok {
+				break
+			}
+			out = append(out, time.TimeOf(next).In(c.Location()).Format("2006-01-02 15:04:05"))
+			nano = next
+		}
+		return out
+	}
+	check := func(spec, from string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%q from %s = %v; want %v", spec, from, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%q from %s = %v; want %v", spec, from, got, want)
+				return
+			}
+		}
+	}
+
+	cases := []struct {
+		spec, from string
+		want       []string
+	}{
+		{"*/15 9-10 * * MON-FRI", "2024-03-08T10:40:00", []string{"2024-03-08 10:45:00", "2024-03-11 09:00:00", "2024-03-11 09:15:00"}},
+		{"0 0 1 * *", "2024-01-31T12:00:00", []string{"2024-02-01 00:00:00", "2024-03-01 00:00:00"}},
+		{"@weekly", "2024-03-06T00:00:00", []string{"2024-03-10 00:00:00", "2024-03-17 00:00:00"}},
+		{"@hourly", "2024-03-06T23:00:00", []string{"2024-03-07 00:00:00"}},
+		{"30 */20 * * * *", "2024-03-06T08:00:00", []string{"2024-03-06 08:00:30", "2024-03-06 08:20:30", "2024-03-06 08:40:30"}},
+		{"0 12 L * *", "2024-01-15T00:00:00", []string{"2024-01-31 12:00:00", "2024-02-29 12:00:00", "2024-03-31 12:00:00"}},
+		{"0 12 L-2 * *", "2024-02-01T00:00:00", []string{"2024-02-27 12:00:00", "2024-03-29 12:00:00"}},
+		{"0 9 LW * *", "2024-03-01T00:00:00", []string{"2024-03-29 09:00:00", "2024-04-30 09:00:00", "2024-05-31 09:00:00", "2024-06-28 09:00:00"}},
+		{"0 9 15W * *", "2024-06-01T00:00:00", []string{"2024-06-14 09:00:00", "2024-07-15 09:00:00", "2024-08-15 09:00:00", "2024-09-16 09:00:00"}},
+		{"0 9 1W * *", "2024-06-01T00:00:00", []string{"2024-06-03 09:00:00"}},
+		{"0 18 * * 5L", "2024-01-01T00:00:00", []string{"2024-01-26 18:00:00", "2024-02-23 18:00:00", "2024-03-29 18:00:00"}},
+		{"0 10 * * TUE#2", "2024-01-01T00:00:00", []string{"2024-01-09 10:00:00", "2024-02-13 10:00:00"}},
+		{"0 0 13 * 5", "2024-09-01T00:00:00", []string{"2024-09-06 00:00:00", "2024-09-13 00:00:00", "2024-09-20 00:00:00", "2024-09-27 00:00:00"}},
+		{"0 0 */2 * 1", "2024-01-01T00:00:00", []string{"2024-01-15 00:00:00", "2024-01-29 00:00:00", "2024-02-05 00:00:00"}},
+		{"0 0 1-31/2 * 1", "2024-01-01T00:00:00", []string{"2024-01-03 00:00:00", "2024-01-05 00:00:00", "2024-01-07 00:00:00", "2024-01-08 00:00:00"}},
+		{"0 0 29 FEB ?", "2024-03-01T00:00:00", []string{"2028-02-29 00:00:00", "2032-02-29 00:00:00"}},
+		{"0 0 * * 7", "2024-03-06T00:00:00", []string{"2024-03-10 00:00:00"}},
+		{"0 0 1 jan-mar/2 *", "2024-01-01T00:00:00", []string{"2024-03-01 00:00:00", "2025-01-01 00:00:00"}},
+		{"0 0 30 2 *", "2024-01-01T00:00:00", nil},
+	}
+	for _, c := range cases {
+		check(c.spec, c.from, runs(c.spec, time.UTC, c.from, max(len(c.want), 1)), c.want...)
+	}
+
+	// DST: skipped wall times run at the shifted instant, repeated ones once.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("30 2 * * *", "2024-03-09", runs("30 2 * * *", ny, "2024-03-09T03:00:00", 2), "2024-03-10 03:30:00", "2024-03-11 02:30:00")
+	check("30 1 * * *", "2024-11-02", runs("30 1 * * *", ny, "2024-11-02T03:00:00", 2), "2024-11-03 01:30:00", "2024-11-04 01:30:00")
+	check("CRON_TZ", "2024-03-09", runs("CRON_TZ=America/New_York 0 9 * * *", time.UTC, "2024-03-09T10:00:00", 2), "2024-03-10 09:00:00", "2024-03-11 09:00:00")
+
+	// Prev mirrors Next.
+	c, err := time.ParseCron("0 9 * * MON-FRI", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev, ok := c.Prev(at("2024-03-11T09:00:00", time.UTC))
+	if !ok || time.TimeOf(prev).UTC().Format("2006-01-02 15:04") != "2024-03-08 09:00" {
+		t.Errorf("Prev = %d, %v", prev, ok)
+	}
+	if next, _ := c.Next(prev); next != at("2024-03-11T09:00:00", time.UTC) {
+		t.Errorf("Next(Prev) = %d", next)
+	}
+	never, _ := time.ParseCron("0 0 30 2 *", time.UTC)
+	if _, ok := never.Prev(at("2024-01-01T00:00:00", time.UTC)); ok {
+		t.Error("Prev of an impossible spec should fail")
+	}
+
+	for _, bad := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "5-1 * * * *", "* * * FOO *", "* * 32W * *", "* * * * MON#6",
+		"@every 5m", "CRON_TZ=Nowhere/Zone * * * * *",
+	} {
+		if _, err := time.ParseCron(bad, time.UTC); err == nil {
+			t.Errorf("ParseCron(%q) should fail", bad)
+		}
+	}
+}
@@ -426,6 +426,17 @@ func IsTodayShared(t *testing.T) {
 		t.Error("IsToday(tomorrow) should return false")
 	}
 
+	// Pin "now" to 23:30 UTC: the next hour is already tomorrow.
+	clock := time.NewFakeClock(1705361400000000000) // 2024-01-15 23:30:00 UTC
+	time.SetClock(clock)
+	defer time.SetClock(nil)
+	if !time.IsToday(1705276800000000000) { // 2024-01-15 00:00
+		t.Error("IsToday(midnight) should return true")
+	}
+	if time.IsToday(1705365000000000000) { // 2024-01-16 00:30
+		t.Error("IsToday(00:30 tomorrow) should return false")
+	}
+
 	t.Logf("IsToday tests passed")
 }
 
@@ -573,27 +584,25 @@ func LocalMinutesToUnixUTCShared(t *testing.T) {
 		t.Errorf("LocalMinutesToUnixUTC(UTC, 09:00) = %d; want %d", result, expected)
 	}
 
-	// UTC-5 (America/New_York winter): 09:00 local == 14:00 UTC
-	result = time.LocalMinutesToUnixUTC(1609459200, 9*60, "America/New_York")
-	expected = int64(1609459200 + 14*3600)
-
-	// In WASM, we only support the global offset.
-	// In the backend, we support full IANA resolution.
-	// Determine if we are in WASM by checking the provider if possible, or just log.
-	if result != expected {
-		// If we are in backend, this is a failure.
-		// If we are in WASM, it's expected unless we set the offset.
-		t.Logf("LocalMinutesToUnixUTC(America/New_York, 09:00) = %d; want %d (divergence expected in WASM if offset is not -300)", result, expected)
-
-		// If we set the offset to -300, it should match even in WASM.
-		// Note: SetTimeZoneOffset takes hours, not minutes.
-		initialOffset := time.GetTimeZoneOffset()
-		time.SetTimeZoneOffset(-5)
-		result = time.LocalMinutesToUnixUTC(1609459200, 9*60, "America/New_York")
-		if result != expected {
-			t.Errorf("LocalMinutesToUnixUTC with offset -5 = %d; want %d", result, expected)
+	// Named zones resolve through their own rules on every platform, not
+	// through the global offset (set here to something unrelated).
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(9)
+	zones := []struct {
+		tz       string
+		dateSec  int64
+		expected int64
+	}{
+		{"America/New_York", 1609459200, 1609459200 + 14*3600}, // 2021-01-01, EST (UTC-5)
+		{"America/New_York", 1625097600, 1625097600 + 13*3600}, // 2021-07-01, EDT (UTC-4)
+		{"America/Santiago", 1609459200, 1609459200 + 12*3600}, // 2021-01-01, CLST (UTC-3)
+		{"Asia/Kolkata", 1609459200, 1609459200 + 9*3600 - 5*3600 - 30*60},
+	}
+	for _, z := range zones {
+		if result := time.LocalMinutesToUnixUTC(z.dateSec, 9*60, z.tz); result != z.expected {
+			t.Errorf("LocalMinutesToUnixUTC(%s, %d, 09:00) = %d; want %d", z.tz, z.dateSec, result, z.expected)
 		}
-		time.SetTimeZoneOffset(initialOffset)
 	}
 
 	// Invalid timezone falls back to UTC
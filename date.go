@@ -0,0 +1,178 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Date is a civil calendar date (year, month, day) with no time of day and
+// no timezone. Use it for values such as birthdates or work-calendar days
+// that must not shift when displayed in another timezone.
+// The zero value is not a valid date; see IsZero.
+type Date struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// NewDate returns the Date for year, month (1-12) and day, or an error if
+// the combination does not exist (e.g. 2023-02-29).
+func NewDate(year int, month Month, day int) (Date, error) {
+	d := Date{Year: year, Month: month, Day: day}
+	if !d.IsValid() {
+		return Date{}, Errf("invalid date: %s-%02d-%02d", formatYear(year), int(month), day)
+	}
+	return d, nil
+}
+
+// ParseCivilDate parses a "YYYY-MM-DD" string into a Date. Years outside
+// 0000-9999 use the expanded form "+YYYYYY-MM-DD" / "-YYYYYY-MM-DD".
+func ParseCivilDate(dateStr string) (Date, error) {
+	n := len(dateStr)
+	if n < 10 || dateStr[n-6] != '-' || dateStr[n-3] != '-' {
+		return Date{}, Errf("invalid date format: %s (expected YYYY-MM-DD)", dateStr)
+	}
+	year, ok1 := parseYear(dateStr[:n-6])
+	month, ok2 := parseDigits(dateStr[n-5 : n-3])
+	day, ok3 := parseDigits(dateStr[n-2:])
+	if !ok1 || !ok2 || !ok3 {
+		return Date{}, Errf("invalid date format: %s", dateStr)
+	}
+	d := Date{Year: year, Month: Month(month), Day: day}
+	if !d.IsValid() {
+		return Date{}, Errf("invalid date: %s", dateStr)
+	}
+	return d, nil
+}
+
+// DateOf returns the calendar date of a UnixNano timestamp in the active timezone offset.
+func DateOf(nano int64) Date {
+	sec, _ := splitNano(nano)
+	return dateFromDays(floorDiv(sec+localOffsetSeconds(), secondsPerDay))
+}
+
+// DateOfUTC returns the calendar date of a UnixNano timestamp in UTC.
+func DateOfUTC(nano int64) Date {
+	sec, _ := splitNano(nano)
+	return dateFromDays(floorDiv(sec, secondsPerDay))
+}
+
+// Today returns the current date in the active timezone offset.
+func Today() Date {
+	return DateOf(Now())
+}
+
+func dateFromDays(days int64) Date {
+	y, m, d := civilFromDays(days)
+	return Date{Year: y, Month: Month(m), Day: d}
+}
+
+// days returns the number of days since 1970-01-01.
+func (d Date) days() int64 {
+	return daysFromCivil(d.Year, int(d.Month), d.Day)
+}
+
+// IsValid reports whether d names an existing calendar day.
+func (d Date) IsValid() bool {
+	return d.Month.IsValid() && d.Day >= 1 && d.Day <= daysInMonth(d.Year, int(d.Month))
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String formats d as "YYYY-MM-DD", or "+YYYYYY-MM-DD" / "-YYYYYY-MM-DD"
+// for years outside 0000-9999.
+func (d Date) String() string {
+	return formatYear(d.Year) + Sprintf("-%02d-%02d", int(d.Month), d.Day)
+}
+
+// AddDays returns the date n days after d (n may be negative).
+func (d Date) AddDays(n int) Date {
+	return dateFromDays(d.days() + int64(n))
+}
+
+// AddMonths returns the date n months after d. When the target month is
+// shorter, the day is clamped to its last day (Jan 31 + 1 month = Feb 28/29).
+func (d Date) AddMonths(n int) Date {
+	return d.AddDate(0, n, 0, Clamp)
+}
+
+// AddDate adds years and months first, resolves a day that does not exist in
+// the resulting month according to overflow, and then adds days.
+func (d Date) AddDate(years, months, days int, overflow Overflow) Date {
+	total := int64(d.Year)*12 + int64(d.Month-1) + int64(years)*12 + int64(months)
+	year := int(floorDiv(total, 12))
+	month := int(floorMod(total, 12)) + 1
+	day := d.Day
+	if last := daysInMonth(year, month); day > last && overflow == Clamp {
+		day = last
+	}
+	// daysFromCivil accepts day overflow, which rolls into the next month.
+	return dateFromDays(daysFromCivil(year, month, day) + int64(days))
+}
+
+// AddYears returns the date n years after d. Feb 29 maps to Feb 28 in non-leap years.
+func (d Date) AddYears(n int) Date {
+	return d.AddMonths(n * 12)
+}
+
+// Compare returns -1 if d is before o, +1 if after and 0 if they are equal.
+func (d Date) Compare(o Date) int {
+	switch {
+	case d.Year != o.Year:
+		return cmpInt(d.Year, o.Year)
+	case d.Month != o.Month:
+		return cmpInt(int(d.Month), int(o.Month))
+	}
+	return cmpInt(d.Day, o.Day)
+}
+
+// Before reports whether d is before o.
+func (d Date) Before(o Date) bool { return d.Compare(o) < 0 }
+
+// After reports whether d is after o.
+func (d Date) After(o Date) bool { return d.Compare(o) > 0 }
+
+// Equal reports whether d and o are the same day.
+func (d Date) Equal(o Date) bool { return d == o }
+
+// DaysSince returns the number of calendar days from o to d (negative if d is before o).
+func (d Date) DaysSince(o Date) int {
+	return int(d.days() - o.days())
+}
+
+// Weekday returns the day of the week.
+func (d Date) Weekday() DayOfWeek {
+	return DayOfWeek(weekdayFromDays(d.days()))
+}
+
+// YearDay returns the day of the year, 1 for January 1st up to 365 or 366.
+func (d Date) YearDay() int {
+	return int(d.days()-daysFromCivil(d.Year, 1, 1)) + 1
+}
+
+// UnixNano returns the UnixNano timestamp of midnight of d in the active
+// timezone offset. Dates outside the UnixNano range (years 1677-2262)
+// saturate to the minimum or maximum int64.
+func (d Date) UnixNano() int64 {
+	nano, _ := unixNanoOf(d.days()*secondsPerDay-localOffsetSeconds(), 0)
+	return nano
+}
+
+// UnixNanoUTC returns the UnixNano timestamp of midnight UTC of d,
+// saturating like UnixNano.
+func (d Date) UnixNanoUTC() int64 {
+	nano, _ := unixNanoOf(d.days()*secondsPerDay, 0)
+	return nano
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
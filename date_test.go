@@ -0,0 +1,92 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test Date
+func DateShared(t *testing.T) {
+	// Validation
+	if _, err := time.NewDate(2023, 2, 29); err == nil {
+		t.Error("NewDate(2023-02-29) should return error")
+	}
+	d, err := time.NewDate(2024, 2, 29)
+	if err != nil {
+		t.Fatalf("NewDate(2024-02-29) failed: %v", err)
+	}
+	if d.String() != "2024-02-29" {
+		t.Errorf("String() = %s; want 2024-02-29", d.String())
+	}
+
+	// Parsing
+	p, err := time.ParseCivilDate("1990-07-15")
+	if err != nil || p != (time.Date{Year: 1990, Month: 7, Day: 15}) {
+		t.Errorf("ParseCivilDate(1990-07-15) = %v, %v", p, err)
+	}
+	for _, bad := range []string{"1990-7-15", "1990/07/15", "1990-02-30", "abcd-01-01"} {
+		if _, err := time.ParseCivilDate(bad); err == nil {
+			t.Errorf("ParseCivilDate(%q) should return error", bad)
+		}
+	}
+
+	// Arithmetic
+	jan31 := time.Date{Year: 2024, Month: 1, Day: 31}
+	if got := jan31.AddMonths(1); got.String() != "2024-02-29" {
+		t.Errorf("AddMonths(1) = %s; want 2024-02-29", got)
+	}
+	if got := jan31.AddMonths(-2); got.String() != "2023-11-30" {
+		t.Errorf("AddMonths(-2) = %s; want 2023-11-30", got)
+	}
+	if got := d.AddYears(1); got.String() != "2025-02-28" {
+		t.Errorf("AddYears(1) = %s; want 2025-02-28", got)
+	}
+	if got := jan31.AddDays(30); got.String() != "2024-03-01" {
+		t.Errorf("AddDays(30) = %s; want 2024-03-01", got)
+	}
+	if got := (time.Date{Year: 1970, Month: 1, Day: 1}).AddDays(-1); got.String() != "1969-12-31" {
+		t.Errorf("AddDays(-1) = %s; want 1969-12-31", got)
+	}
+
+	// Comparisons
+	if !jan31.Before(d) || !d.After(jan31) || d.Compare(d) != 0 {
+		t.Error("Date comparisons failed")
+	}
+	if d.DaysSince(jan31) != 29 {
+		t.Errorf("DaysSince = %d; want 29", d.DaysSince(jan31))
+	}
+
+	// Day of week / year
+	if wd := (time.Date{Year: 2024, Month: 1, Day: 7}).Weekday(); wd != 0 {
+		t.Errorf("Weekday(2024-01-07) = %d; want 0", wd)
+	}
+	if yd := (time.Date{Year: 2024, Month: 12, Day: 31}).YearDay(); yd != 366 {
+		t.Errorf("YearDay(2024-12-31) = %d; want 366", yd)
+	}
+
+	// Conversions are independent of the offset for UTC and shift for local
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+
+	birth := time.Date{Year: 2021, Month: 1, Day: 1}
+	if birth.UnixNanoUTC() != GlobalTestUnixNano {
+		t.Errorf("UnixNanoUTC = %d; want %d", birth.UnixNanoUTC(), GlobalTestUnixNano)
+	}
+	if birth.UnixNano() != GlobalTestUnixNano+3*3600*1000000000 {
+		t.Errorf("UnixNano(UTC-3) = %d; want local midnight", birth.UnixNano())
+	}
+	if got := time.DateOf(birth.UnixNano()); got != birth {
+		t.Errorf("DateOf(local midnight) = %s; want %s", got, birth)
+	}
+	if got := time.DateOfUTC(birth.UnixNanoUTC()); got != birth {
+		t.Errorf("DateOfUTC = %s; want %s", got, birth)
+	}
+	if got := time.DateOf(GlobalTestUnixNano); got.String() != "2020-12-31" {
+		t.Errorf("DateOf(UTC midnight, UTC-3) = %s; want 2020-12-31", got)
+	}
+	if got := time.DateOfUTC(-1); got.String() != "1969-12-31" {
+		t.Errorf("DateOfUTC(-1) = %s; want 1969-12-31", got)
+	}
+}
@@ -0,0 +1,254 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// TimeOfDay is a wall-clock time within a day with no date and no timezone.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayFromMinutes converts minutes since midnight (as returned by ParseTime) into a TimeOfDay.
+func TimeOfDayFromMinutes(minutes int16) TimeOfDay {
+	return TimeOfDay{Hour: int(minutes) / 60, Minute: int(minutes) % 60}
+}
+
+// ParseTimeOfDay parses "HH:MM", "HH:MM:SS" or "HH:MM:SS.fffffffff".
+func ParseTimeOfDay(timeStr string) (TimeOfDay, error) {
+	var t TimeOfDay
+	if len(timeStr) < 5 || timeStr[2] != ':' {
+		return t, Errf("invalid time format: %s", timeStr)
+	}
+	var ok1, ok2, ok3 bool
+	t.Hour, ok1 = parseDigits(timeStr[0:2])
+	t.Minute, ok2 = parseDigits(timeStr[3:5])
+	ok3 = true
+	rest := timeStr[5:]
+	if rest != "" {
+		if len(rest) < 3 || rest[0] != ':' {
+			return TimeOfDay{}, Errf("invalid time format: %s", timeStr)
+		}
+		t.Second, ok3 = parseDigits(rest[1:3])
+		if ok3 && len(rest) > 3 {
+			t.Nanosecond, ok3 = parseFraction(rest[3:])
+		}
+	}
+	if !ok1 || !ok2 || !ok3 || !t.IsValid() {
+		return TimeOfDay{}, Errf("invalid time: %s", timeStr)
+	}
+	return t, nil
+}
+
+// parseFraction parses ".d" … ".ddddddddd" into nanoseconds.
+func parseFraction(s string) (int, bool) {
+	if len(s) < 2 || len(s) > 10 || (s[0] != '.' && s[0] != ',') {
+		return 0, false
+	}
+	n, ok := parseDigits(s[1:])
+	for i := len(s) - 1; i < 9; i++ {
+		n *= 10
+	}
+	return n, ok
+}
+
+// IsValid reports whether every field is within range.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 && t.Nanosecond < nanosPerSecond
+}
+
+// Minutes returns the minutes since midnight, the format used by ParseTime.
+func (t TimeOfDay) Minutes() int16 {
+	return int16(t.Hour*60 + t.Minute)
+}
+
+// String formats t as "HH:MM:SS", followed by the fraction of a second when it is not zero.
+func (t TimeOfDay) String() string {
+	s := Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += formatFraction(t.Nanosecond)
+	}
+	return s
+}
+
+// formatFraction returns ".fffffffff" without trailing zeros.
+func formatFraction(nsec int) string {
+	buf := []byte(".000000000")
+	for i := 9; i > 0; i-- {
+		buf[i] = byte('0' + nsec%10)
+		nsec /= 10
+	}
+	end := len(buf)
+	for end > 1 && buf[end-1] == '0' {
+		end--
+	}
+	return string(buf[:end])
+}
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or after o.
+func (t TimeOfDay) Compare(o TimeOfDay) int {
+	a, b := t.nanos(), o.nanos()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// nanos returns the nanoseconds elapsed since midnight.
+func (t TimeOfDay) nanos() int64 {
+	return int64(t.Hour*secondsPerHour+t.Minute*secondsPerMinute+t.Second)*nanosPerSecond + int64(t.Nanosecond)
+}
+
+func timeOfDayFromNanos(n int64) TimeOfDay {
+	sec := n / nanosPerSecond
+	return TimeOfDay{
+		Hour:       int(sec / secondsPerHour),
+		Minute:     int(sec % secondsPerHour / secondsPerMinute),
+		Second:     int(sec % secondsPerMinute),
+		Nanosecond: int(n % nanosPerSecond),
+	}
+}
+
+// DateTime is a civil date and wall-clock time with no timezone, such as
+// "2024-03-10 09:30 in the clinic's zone". Convert it to an instant with
+// UnixNanoIn once the zone is known.
+type DateTime struct {
+	Date Date
+	Time TimeOfDay
+}
+
+// ParseCivilDateTime parses "YYYY-MM-DD HH:MM[:SS[.fffffffff]]"; a 'T'
+// separator is also accepted, and the date may use an expanded year.
+func ParseCivilDateTime(s string) (DateTime, error) {
+	sep := 0
+	for sep < len(s) && s[sep] != ' ' && s[sep] != 'T' {
+		sep++
+	}
+	if sep < 10 || len(s)-sep < 6 {
+		return DateTime{}, Errf("invalid date-time format: %s", s)
+	}
+	d, err := ParseCivilDate(s[:sep])
+	if err != nil {
+		return DateTime{}, err
+	}
+	t, err := ParseTimeOfDay(s[sep+1:])
+	if err != nil {
+		return DateTime{}, err
+	}
+	return DateTime{Date: d, Time: t}, nil
+}
+
+// DateTimeOf returns the wall-clock date and time of a UnixNano instant in loc.
+func DateTimeOf(nano int64, loc *Location) DateTime {
+	sec, nsec := splitNano(nano)
+	return dateTimeFromLocal(sec+loc.offsetAt(sec), nsec)
+}
+
+// dateTimeFromLocal builds a DateTime from local seconds since the epoch.
+func dateTimeFromLocal(local, nsec int64) DateTime {
+	days := floorDiv(local, secondsPerDay)
+	sod := local - days*secondsPerDay
+	return DateTime{
+		Date: dateFromDays(days),
+		Time: timeOfDayFromNanos(sod*nanosPerSecond + nsec),
+	}
+}
+
+// localSeconds returns the wall-clock seconds since 1970-01-01 00:00:00 and the nanosecond part.
+func (dt DateTime) localSeconds() (int64, int64) {
+	n := dt.Time.nanos()
+	return dt.Date.days()*secondsPerDay + n/nanosPerSecond, n % nanosPerSecond
+}
+
+// IsZero reports whether dt is the zero DateTime.
+func (dt DateTime) IsZero() bool {
+	return dt == DateTime{}
+}
+
+// IsValid reports whether both the date and the time of day are valid.
+func (dt DateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Time.IsValid()
+}
+
+// String formats dt as "YYYY-MM-DD HH:MM:SS", with a fraction when it is not zero.
+func (dt DateTime) String() string {
+	return dt.Date.String() + " " + dt.Time.String()
+}
+
+// Add returns dt moved by nanos on the wall clock, ignoring any timezone transitions.
+func (dt DateTime) Add(nanos int64) DateTime {
+	sec, nsec := dt.localSeconds()
+	ds, dn := splitNano(nsec + nanos)
+	return dateTimeFromLocal(sec+ds, dn)
+}
+
+// AddDays returns dt with its date moved by n days; the time of day is kept.
+func (dt DateTime) AddDays(n int) DateTime {
+	return DateTime{Date: dt.Date.AddDays(n), Time: dt.Time}
+}
+
+// AddMonths returns dt with its date moved by n months (see Date.AddMonths).
+func (dt DateTime) AddMonths(n int) DateTime {
+	return DateTime{Date: dt.Date.AddMonths(n), Time: dt.Time}
+}
+
+// AddYears returns dt with its date moved by n years (see Date.AddYears).
+func (dt DateTime) AddYears(n int) DateTime {
+	return DateTime{Date: dt.Date.AddYears(n), Time: dt.Time}
+}
+
+// Sub returns the wall-clock nanoseconds from o to dt.
+func (dt DateTime) Sub(o DateTime) int64 {
+	s1, n1 := dt.localSeconds()
+	s2, n2 := o.localSeconds()
+	return (s1-s2)*nanosPerSecond + n1 - n2
+}
+
+// Compare returns -1, 0 or +1 depending on whether dt is before, equal to or after o.
+func (dt DateTime) Compare(o DateTime) int {
+	if c := dt.Date.Compare(o.Date); c != 0 {
+		return c
+	}
+	return dt.Time.Compare(o.Time)
+}
+
+// Before reports whether dt is before o.
+func (dt DateTime) Before(o DateTime) bool { return dt.Compare(o) < 0 }
+
+// After reports whether dt is after o.
+func (dt DateTime) After(o DateTime) bool { return dt.Compare(o) > 0 }
+
+// Equal reports whether dt and o are the same wall-clock time.
+func (dt DateTime) Equal(o DateTime) bool { return dt == o }
+
+// UnixNanoIn converts dt into a UnixNano instant in loc. Wall times skipped
+// or repeated by a DST transition are resolved according to policy; with
+// Reject such times return an error, as do instants outside the UnixNano
+// range (years 1677-2262).
+func (dt DateTime) UnixNanoIn(loc *Location, policy Disambiguation) (int64, error) {
+	sec, nsec := dt.localSeconds()
+	unix, err := loc.resolve(sec, policy)
+	if err != nil {
+		return 0, Errf("%s: %v", dt.String(), err)
+	}
+	nano, ok := unixNanoOf(unix, nsec)
+	if !ok {
+		return 0, Errf("%s: out of the UnixNano range", dt.String())
+	}
+	return nano, nil
+}
+
+// UnixNanoOffset converts dt into a UnixNano instant using a fixed offset
+// in seconds east of UTC, saturating outside the UnixNano range.
+func (dt DateTime) UnixNanoOffset(offsetSeconds int) int64 {
+	sec, nsec := dt.localSeconds()
+	nano, _ := unixNanoOf(sec-int64(offsetSeconds), nsec)
+	return nano
+}
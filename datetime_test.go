@@ -0,0 +1,101 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test TimeOfDay and DateTime
+func DateTimeShared(t *testing.T) {
+	tod, err := time.ParseTimeOfDay("08:30:15.25")
+	if err != nil {
+		t.Fatalf("ParseTimeOfDay failed: %v", err)
+	}
+	if tod != (time.TimeOfDay{Hour: 8, Minute: 30, Second: 15, Nanosecond: 250000000}) {
+		t.Errorf("ParseTimeOfDay = %+v", tod)
+	}
+	if tod.String() != "08:30:15.25" || tod.Minutes() != 510 {
+		t.Errorf("TimeOfDay String/Minutes = %s/%d", tod.String(), tod.Minutes())
+	}
+	if time.TimeOfDayFromMinutes(510).String() != "08:30:00" {
+		t.Errorf("TimeOfDayFromMinutes(510) = %s", time.TimeOfDayFromMinutes(510))
+	}
+	for _, bad := range []string{"8:30", "24:00", "08:60", "08:30:", "08:30:15.", "08:30:15.1234567890"} {
+		if _, err := time.ParseTimeOfDay(bad); err == nil {
+			t.Errorf("ParseTimeOfDay(%q) should return error", bad)
+		}
+	}
+
+	dt, err := time.ParseCivilDateTime("2024-01-31T23:30")
+	if err != nil {
+		t.Fatalf("ParseCivilDateTime failed: %v", err)
+	}
+	if dt.String() != "2024-01-31 23:30:00" {
+		t.Errorf("String() = %s", dt.String())
+	}
+	if got := dt.Add(45 * 60 * 1000000000); got.String() != "2024-02-01 00:15:00" {
+		t.Errorf("Add(45m) = %s", got)
+	}
+	if got := dt.Add(-24 * 3600 * 1000000000); got.String() != "2024-01-30 23:30:00" {
+		t.Errorf("Add(-24h) = %s", got)
+	}
+	if got := dt.AddMonths(1); got.String() != "2024-02-29 23:30:00" {
+		t.Errorf("AddMonths(1) = %s", got)
+	}
+	later := dt.AddDays(1)
+	if !dt.Before(later) || later.Sub(dt) != 24*3600*1000000000 {
+		t.Error("DateTime comparison/Sub failed")
+	}
+
+	// Fixed offsets
+	if got := dt.UnixNanoOffset(-3 * 3600); time.DateTimeOf(got, time.FixedZone("UTC-3", -3*3600)) != dt {
+		t.Errorf("UnixNanoOffset round trip failed: %s", time.DateTimeOf(got, time.FixedZone("", -3*3600)))
+	}
+	if got, _ := dt.UnixNanoIn(time.UTC, time.Reject); time.FormatISO8601(got) != "2024-01-31T23:30:00Z" {
+		t.Errorf("UnixNanoIn(UTC) = %s", time.FormatISO8601(got))
+	}
+	if got := time.DateTimeOf(-1, time.UTC); got.String() != "1969-12-31 23:59:59.999999999" {
+		t.Errorf("DateTimeOf(-1) = %s", got)
+	}
+}
+
+// Test Location with DST gaps and overlaps
+func LocationShared(t *testing.T) {
+	if _, err := time.LoadLocation("Invalid/Zone"); err == nil {
+		t.Error("LoadLocation(Invalid/Zone) should return error")
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	if off := ny.Offset(1719838800 * 1000000000); off != -4*3600 {
+		t.Errorf("Offset(July) = %d; want -14400", off)
+	}
+
+	const s = 1000000000
+	gap := time.DateTime{Date: time.Date{Year: 2024, Month: 3, Day: 10}, Time: time.TimeOfDay{Hour: 2, Minute: 30}}
+	cases := []struct {
+		dt     time.DateTime
+		policy time.Disambiguation
+		want   int64
+	}{
+		{gap, time.Compatible, 1710055800 * s},
+		{gap, time.Later, 1710055800 * s},
+		{gap, time.Earlier, 1710052200 * s},
+		{gap.AddMonths(8).AddDays(-7).Add(-3600 * s), time.Compatible, 1730611800 * s}, // 2024-11-03 01:30
+		{gap.AddMonths(8).AddDays(-7).Add(-3600 * s), time.Later, 1730615400 * s},
+	}
+	for _, c := range cases {
+		got, err := c.dt.UnixNanoIn(ny, c.policy)
+		if err != nil || got != c.want {
+			t.Errorf("UnixNanoIn(%s, %d) = %d, %v; want %d", c.dt, c.policy, got, err, c.want)
+		}
+	}
+	if _, err := gap.UnixNanoIn(ny, time.Reject); err == nil {
+		t.Error("UnixNanoIn(gap, Reject) should return error")
+	}
+	if got := time.DateTimeOf(1710055800*s, ny); got.String() != "2024-03-10 03:30:00" {
+		t.Errorf("DateTimeOf(after gap) = %s", got)
+	}
+}
@@ -13,3 +13,10 @@ func FireTimer(t time.Timer) {
 		wt.Fire()
 	}
 }
+
+// TickTicker triggers one tick of a WASM ticker manually.
+func TickTicker(t time.Ticker) {
+	if wt, ok := t.(*time.WasmTicker); ok {
+		wt.Tick()
+	}
+}
@@ -2,214 +2,235 @@
 
 package time
 
-import (
-	"syscall/js"
+import "syscall/js"
 
-	. "github.com/tinywasm/fmt"
-)
-
-func init() {
-	provider = &timeClient{
+func newDefaultProvider() Provider {
+	tc := &timeClient{
 		dateCtor: js.Global().Get("Date"),
+		perf:     js.Global().Get("performance"),
+		zones:    make(map[string]js.Value),
+	}
+	if tc.perf.Truthy() && tc.perf.Get("timeOrigin").Truthy() {
+		tc.originNano = msToNano(tc.perf.Get("timeOrigin").Float())
 	}
+	return tc
+}
+
+// msToNano converts fractional JS milliseconds to nanoseconds, splitting
+// off the whole milliseconds first: a UnixNano does not fit in the 53-bit
+// float64 mantissa.
+func msToNano(ms float64) int64 {
+	whole := int64(ms)
+	return whole*1000000 + int64((ms-float64(whole))*1e6)
 }
 
-// timeClient implements timeProvider for WASM/JS environments using the JavaScript Date API.
+// timeClient implements Provider for WASM/JS environments: clocks, zones
+// and timers come from JavaScript, while formatting and parsing use the
+// integer civil arithmetic of PortableProvider, so values keep their
+// nanoseconds and match the backend exactly.
 type timeClient struct {
-	dateCtor js.Value
+	PortableProvider
+	dateCtor   js.Value
+	perf       js.Value            // performance; undefined in hosts without it
+	originNano int64               // performance.timeOrigin as UnixNano; 0 without it
+	zones      map[string]js.Value // Intl.DateTimeFormat per IANA name; undefined if unknown
 }
 
+// UnixNano reads performance.timeOrigin + performance.now() for
+// sub-millisecond precision, falling back to whole milliseconds from
+// Date.now() where the host lacks them.
 func (tc *timeClient) UnixNano() int64 {
-	jsDate := tc.dateCtor.New()
-	msTimestamp := jsDate.Call("getTime").Float()
-	return int64(msTimestamp) * 1000000
+	if tc.originNano != 0 {
+		return tc.originNano + msToNano(tc.perf.Call("now").Float())
+	}
+	return int64(tc.dateCtor.Call("now").Float()) * 1000000
 }
 
-func (tc *timeClient) applyOffset(nano int64) js.Value {
-	offsetMs := float64(getOffsetMinutes()) * 60000
-	return tc.dateCtor.New(float64(nano)/1e6 + offsetMs)
+// Monotonic reads performance.now(), falling back to Date.now() where
+// the host has no performance object.
+func (tc *timeClient) Monotonic() int64 {
+	if tc.perf.Truthy() {
+		return msToNano(tc.perf.Call("now").Float())
+	}
+	return int64(tc.dateCtor.Call("now").Float()) * 1000000
 }
 
-func (tc *timeClient) FormatDate(value any) string {
-	switch v := value.(type) {
-	case int64:
-		jsDate := tc.applyOffset(v)
-		return jsDate.Call("toISOString").String()[0:10]
-	case string:
-		if len(v) == 10 && v[4] == '-' && v[7] == '-' {
-			return v
-		}
+// jsMinZoneSec and jsMaxZoneSec bound the instants passed to Intl: JS Date
+// stops at ±8.64e15 ms, and before year 1 Intl reports era years. Zone
+// rules are constant far from the present, so clamping keeps the offset.
+const (
+	jsMinZoneSec = -62135596800 // 0001-01-01
+	jsMaxZoneSec = 8640000000000
+)
+
+// ZoneOffset reads the wall-clock fields of the instant in tz through
+// Intl.DateTimeFormat and returns their distance to UTC.
+func (tc *timeClient) ZoneOffset(tz string, unixSec int64) (int, bool) {
+	dtf := tc.zoneFormatter(tz)
+	if dtf.IsUndefined() {
+		return 0, false
 	}
-	return ""
-}
-
-func (tc *timeClient) FormatTime(value any) string {
-	switch v := value.(type) {
-	case int64: // UnixNano
-		jsDate := tc.applyOffset(v)
-		hours := jsDate.Call("getUTCHours").Int()
-		minutes := jsDate.Call("getUTCMinutes").Int()
-		seconds := jsDate.Call("getUTCSeconds").Int()
-		return Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-	case int16: // Minutes since midnight
-		hours := v / 60
-		minutes := v % 60
-		return Sprintf("%02d:%02d", hours, minutes)
-	case string:
-		if nano, err := Convert(v).Int64(); err == nil {
-			jsDate := tc.applyOffset(nano)
-			hours := jsDate.Call("getUTCHours").Int()
-			minutes := jsDate.Call("getUTCMinutes").Int()
-			seconds := jsDate.Call("getUTCSeconds").Int()
-			return Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-		}
-		if Count(v, ":") >= 1 {
-			return v
+	unixSec = min(max(unixSec, jsMinZoneSec), jsMaxZoneSec)
+	parts := dtf.Call("formatToParts", float64(unixSec)*1000)
+	var year, month, day, hour, minute, second int
+	for i := 0; i < parts.Length(); i++ {
+		part := parts.Index(i)
+		n, _ := parseDigits(part.Get("value").String())
+		switch part.Get("type").String() {
+		case "year":
+			year = n
+		case "month":
+			month = n
+		case "day":
+			day = n
+		case "hour":
+			hour = n % 24
+		case "minute":
+			minute = n
+		case "second":
+			second = n
 		}
 	}
-	return ""
-}
-
-func (tc *timeClient) FormatDateTime(value any) string {
-	switch v := value.(type) {
-	case int64:
-		jsDate := tc.applyOffset(v)
-		iso := jsDate.Call("toISOString").String()
-		return iso[0:10] + " " + iso[11:19]
-	case string:
-		if len(v) == 19 && v[4] == '-' && v[7] == '-' && v[10] == ' ' && v[13] == ':' && v[16] == ':' {
-			return v
-		}
+	local := daysFromCivil(year, month, day)*secondsPerDay + int64(hour*secondsPerHour+minute*secondsPerMinute+second)
+	return int(local - unixSec), true
+}
+
+func (tc *timeClient) zoneFormatter(tz string) (dtf js.Value) {
+	if v, ok := tc.zones[tz]; ok {
+		return v
 	}
-	return ""
-}
-
-func (tc *timeClient) FormatDateTimeShort(value any) string {
-	switch v := value.(type) {
-	case int64:
-		jsDate := tc.applyOffset(v)
-		iso := jsDate.Call("toISOString").String()
-		return iso[0:10] + " " + iso[11:16]
-	case string:
-		if len(v) == 16 && v[4] == '-' && v[7] == '-' && v[10] == ' ' && v[13] == ':' {
-			return v
+	defer func() {
+		// Intl throws a RangeError for unknown zones.
+		if recover() != nil {
+			dtf = js.Undefined()
 		}
-	}
-	return ""
+		tc.zones[tz] = dtf
+	}()
+	return js.Global().Get("Intl").Get("DateTimeFormat").New("en-US", map[string]any{
+		"timeZone":  tz,
+		"hourCycle": "h23",
+		"year":      "numeric",
+		"month":     "numeric",
+		"day":       "numeric",
+		"hour":      "numeric",
+		"minute":    "numeric",
+		"second":    "numeric",
+	})
 }
 
-func (tc *timeClient) FormatISO8601(nano int64) string {
-	// We MUST NOT use applyOffset here. We need raw UTC.
-	jsDate := tc.dateCtor.New(float64(nano) / 1e6)
-
-	// 'toISOString' natively outputs 'YYYY-MM-DDTHH:mm:ss.sssZ'
-	iso := jsDate.Call("toISOString").String()
-
-	// We slice out the milliseconds to strictly match 'YYYY-MM-DDTHH:MM:SSZ'
-	return iso[0:19] + "Z"
+type WasmTimer struct {
+	tc       *timeClient
+	id       js.Value
+	active   bool
+	jsFunc   js.Func
+	f        func()
+	deadline int64 // Monotonic reading, unaffected by SetProvider or wall clock jumps
 }
 
-func (tc *timeClient) FormatCompact(nano int64) string {
-	jsDate := tc.dateCtor.New(float64(nano) / 1e6)
-	iso := jsDate.Call("toISOString").String()
-	// iso = "YYYY-MM-DDTHH:mm:ss.sssZ"
-	// extraer: YYYY(0:4) MM(5:7) DD(8:10) HH(11:13) mm(14:16) ss(17:19)
-	return iso[0:4] + iso[5:7] + iso[8:10] + iso[11:13] + iso[14:16] + iso[17:19]
+func (wt *WasmTimer) Stop() bool {
+	if !wt.active {
+		return false
+	}
+	js.Global().Call("clearTimeout", wt.id)
+	wt.active = false
+	wt.jsFunc.Release()
+	return true
 }
 
-func (tc *timeClient) ParseDate(dateStr string) (int64, error) {
-	if len(dateStr) != 10 || dateStr[4] != '-' || dateStr[7] != '-' {
-		return 0, Errf("invalid date format: %s (expected YYYY-MM-DD)", dateStr)
-	}
-	jsDate := tc.dateCtor.New(dateStr + "T00:00:00Z")
-	if jsDate.Call("toString").String() == "Invalid Date" {
-		return 0, Errf("invalid date format: %s", dateStr)
-	}
-	year := jsDate.Call("getUTCFullYear").Int()
-	month := jsDate.Call("getUTCMonth").Int() + 1
-	day := jsDate.Call("getUTCDate").Int()
-	expected := Sprintf("%04d-%02d-%02d", year, month, day)
-	if expected != dateStr {
-		return 0, Errf("invalid date: %s (auto-corrected to %s)", dateStr, expected)
+// Reset re-arms the timer, reusing its js.Func while it is still active.
+func (wt *WasmTimer) Reset(milliseconds int) bool {
+	if !wt.active {
+		wt.start(milliseconds)
+		return false
 	}
-	ms := jsDate.Call("getTime").Float()
-	return int64(ms) * 1000000, nil
+	js.Global().Call("clearTimeout", wt.id)
+	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds)
+	wt.deadline = wt.tc.Monotonic() + int64(milliseconds)*1_000_000
+	return true
 }
 
-func (tc *timeClient) ParseTime(timeStr string) (int16, error) {
-	return parseTime(timeStr)
+func (wt *WasmTimer) Pending() bool {
+	return wt.active
 }
 
-func (tc *timeClient) ParseDateTime(dateStr, timeStr string) (int64, error) {
-	if len(timeStr) == 5 {
-		timeStr += ":00"
-	}
-	isoStr := dateStr + "T" + timeStr + "Z"
-	jsDate := tc.dateCtor.New(isoStr)
-	if jsDate.Call("toString").String() == "Invalid Date" {
-		return 0, Errf("invalid date/time format: %s %s", dateStr, timeStr)
+func (wt *WasmTimer) Remaining() int {
+	if !wt.active {
+		return 0
 	}
-	ms := jsDate.Call("getTime").Float()
-	return int64(ms) * 1000000, nil
+	left := wt.deadline - wt.tc.Monotonic()
+	return int(max(left+999_999, 0) / 1_000_000)
 }
 
-func (tc *timeClient) IsToday(nano int64) bool {
-	jsDateLocal := tc.applyOffset(nano)
-	nowLocal := tc.applyOffset(tc.UnixNano())
-	return jsDateLocal.Call("toDateString").String() == nowLocal.Call("toDateString").String()
-}
-
-func (tc *timeClient) IsPast(nano int64) bool {
-	return nano < tc.UnixNano()
+func (wt *WasmTimer) Fire() {
+	if !wt.active {
+		return
+	}
+	js.Global().Call("clearTimeout", wt.id)
+	wt.active = false
+	wt.jsFunc.Release()
+	if wt.f != nil {
+		wt.f()
+	}
 }
 
-func (tc *timeClient) IsFuture(nano int64) bool {
-	return nano > tc.UnixNano()
+func (wt *WasmTimer) start(milliseconds int) {
+	wt.jsFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+		wt.Fire()
+		return nil
+	})
+	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds)
+	wt.deadline = wt.tc.Monotonic() + int64(milliseconds)*1_000_000
+	wt.active = true
 }
 
-func (tc *timeClient) LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz string) int64 {
-	offsetSec := int64(getOffsetMinutes()) * 60
-	midnight := MidnightUTC(dateSec)
-	return midnight + int64(localMinutes)*60 - offsetSec
+func (tc *timeClient) AfterFunc(milliseconds int, f func()) Timer {
+	wt := &WasmTimer{tc: tc, f: f}
+	wt.start(milliseconds)
+	return wt
 }
 
-type WasmTimer struct {
+type WasmTicker struct {
 	id     js.Value
 	active bool
 	jsFunc js.Func
 	f      func()
 }
 
-func (wt *WasmTimer) Stop() bool {
+// Stop clears the interval and releases its js.Func. Returns true if the ticker was active.
+func (wt *WasmTicker) Stop() bool {
 	if !wt.active {
 		return false
 	}
-	js.Global().Call("clearTimeout", wt.id)
+	js.Global().Call("clearInterval", wt.id)
 	wt.active = false
 	wt.jsFunc.Release()
 	return true
 }
 
-func (wt *WasmTimer) Fire() {
-	if !wt.active {
-		return
-	}
-	wt.active = false
-	wt.jsFunc.Release()
-	if wt.f != nil {
+// Reset replaces the interval with a new one of the given period.
+func (wt *WasmTicker) Reset(milliseconds int) {
+	wt.Stop()
+	wt.start(milliseconds)
+}
+
+// Tick runs the callback if the ticker is active.
+func (wt *WasmTicker) Tick() {
+	if wt.active && wt.f != nil {
 		wt.f()
 	}
 }
 
-func (tc *timeClient) AfterFunc(milliseconds int, f func()) Timer {
-	wt := &WasmTimer{
-		active: true,
-		f:      f,
-	}
+func (wt *WasmTicker) start(milliseconds int) {
 	wt.jsFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
-		wt.Fire()
+		wt.Tick()
 		return nil
 	})
-	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds)
+	wt.id = js.Global().Call("setInterval", wt.jsFunc, max(milliseconds, 1))
+	wt.active = true
+}
+
+func (tc *timeClient) TickFunc(milliseconds int, f func()) Ticker {
+	wt := &WasmTicker{f: f}
+	wt.start(milliseconds)
 	return wt
 }
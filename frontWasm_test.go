@@ -4,9 +4,25 @@ package time_test
 
 import (
 	"testing"
+
+	"github.com/tinywasm/time"
 )
 
 // TestTimeAPIWasm tests the WASM/JS time API implementation.
 func TestTimeAPIWasm(t *testing.T) {
 	RunAPITests(t)
 }
+
+// TestNowSubMillisecond checks that Now is not truncated to whole
+// milliseconds: successive readings can differ by less than 1 ms.
+func TestNowSubMillisecond(t *testing.T) {
+	prev := time.Now()
+	for i := 0; i < 100000; i++ {
+		now := time.Now()
+		if d := now - prev; d > 0 && d < 1000000 {
+			return
+		}
+		prev = now
+	}
+	t.Error("no two successive Now readings differed by less than 1 ms")
+}
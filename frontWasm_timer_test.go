@@ -96,3 +96,84 @@ func TestAfterFunc_NilCallback(t *testing.T) {
 	timer.Stop()
 	t.Log("AfterFunc nil callback - passed")
 }
+
+func TestTickFunc_StopAndReset(t *testing.T) {
+	ticks := 0
+	ticker := time.TickFunc(1000, func() { ticks++ })
+
+	TickTicker(ticker)
+	TickTicker(ticker)
+	if ticks != 2 {
+		t.Errorf("ticks = %d; want 2", ticks)
+	}
+	if !ticker.Stop() {
+		t.Error("Stop() should return true for active ticker")
+	}
+	if ticker.Stop() {
+		t.Error("Stop() should return false for stopped ticker")
+	}
+	TickTicker(ticker)
+	if ticks != 2 {
+		t.Error("stopped ticker should not tick")
+	}
+
+	ticker.Reset(500)
+	TickTicker(ticker)
+	if ticks != 3 {
+		t.Errorf("ticks after Reset = %d; want 3", ticks)
+	}
+	if !ticker.Stop() {
+		t.Error("Reset should reactivate the ticker")
+	}
+}
+
+func TestTickFuncCompensated_Stop(t *testing.T) {
+	ticker := time.TickFuncCompensated(1000, func() {})
+	if !ticker.Stop() || ticker.Stop() {
+		t.Error("Stop() should return true once")
+	}
+}
+
+func TestAfterFunc_ResetAndPending(t *testing.T) {
+	fired := 0
+	timer := time.AfterFunc(1000, func() { fired++ })
+	if !timer.Pending() {
+		t.Error("new timer should be pending")
+	}
+	if r := timer.Remaining(); r < 900 || r > 1000 {
+		t.Errorf("Remaining = %d; want about 1000", r)
+	}
+	if !timer.Reset(5000) {
+		t.Error("Reset of a pending timer should return true")
+	}
+	if r := timer.Remaining(); r < 4900 || r > 5000 {
+		t.Errorf("Remaining after Reset = %d; want about 5000", r)
+	}
+	// The deadline is monotonic: swapping the wall clock does not move it.
+	time.SetProvider(fixedProvider{now: 1})
+	r := timer.Remaining()
+	time.SetProvider(nil)
+	if r < 4900 || r > 5000 {
+		t.Errorf("Remaining after SetProvider = %d; want about 5000", r)
+	}
+
+	FireTimer(timer)
+	if fired != 1 || timer.Pending() || timer.Remaining() != 0 {
+		t.Errorf("fired = %d, pending = %v", fired, timer.Pending())
+	}
+	if timer.Reset(1000) {
+		t.Error("Reset of a fired timer should return false")
+	}
+	if !timer.Pending() {
+		t.Error("Reset should re-arm the timer")
+	}
+	FireTimer(timer)
+	if fired != 2 {
+		t.Errorf("fired = %d; want 2", fired)
+	}
+	timer.Reset(1000)
+	timer.Stop()
+	if timer.Pending() {
+		t.Error("stopped timer should not be pending")
+	}
+}
@@ -0,0 +1,247 @@
+package time
+
+import (
+	"sync"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Holiday is a public holiday on a civil date.
+type Holiday struct {
+	Date     Date
+	Name     string
+	Observed bool // moved from its nominal date by an adjuster
+}
+
+// HolidayAdjuster moves a nominal holiday date to the day it is observed.
+type HolidayAdjuster func(Date) Date
+
+// HolidayRule generates one holiday per year. Build it with FixedHoliday,
+// NthWeekdayHoliday, EasterHoliday or CustomHoliday and refine it with
+// Observe and Years.
+type HolidayRule struct {
+	Name     string
+	nominal  func(year int) (Date, bool)
+	adjust   HolidayAdjuster
+	from, to int // year range; 0 means unbounded
+}
+
+// FixedHoliday is celebrated on the same month and day every year.
+func FixedHoliday(name string, month Month, day int) HolidayRule {
+	return CustomHoliday(name, func(year int) (Date, bool) {
+		return Date{Year: year, Month: month, Day: day}, true
+	})
+}
+
+// NthWeekdayHoliday falls on the nth weekday of month, e.g. the third Monday
+// of January. A negative n counts from the end: -1 is the last one.
+func NthWeekdayHoliday(name string, month Month, weekday DayOfWeek, n int) HolidayRule {
+	return CustomHoliday(name, func(year int) (Date, bool) {
+		return nthWeekday(year, month, weekday, n)
+	})
+}
+
+// EasterHoliday falls offset days after Easter Sunday (negative for before),
+// e.g. Good Friday is EasterHoliday("Good Friday", -2).
+func EasterHoliday(name string, offset int) HolidayRule {
+	return CustomHoliday(name, func(year int) (Date, bool) {
+		return Easter(year).AddDays(offset), true
+	})
+}
+
+// CustomHoliday uses date to compute the nominal date of each year; it
+// returns false for years without the holiday.
+func CustomHoliday(name string, date func(year int) (Date, bool)) HolidayRule {
+	return HolidayRule{Name: name, nominal: date}
+}
+
+// Observe returns a copy of r whose dates are moved by adjust.
+func (r HolidayRule) Observe(adjust HolidayAdjuster) HolidayRule {
+	r.adjust = adjust
+	return r
+}
+
+// Years returns a copy of r limited to the years from..to inclusive; 0 leaves a side open.
+func (r HolidayRule) Years(from, to int) HolidayRule {
+	r.from, r.to = from, to
+	return r
+}
+
+// On returns the holiday generated by r for a rule year. The observed date
+// may fall in a neighbouring year (e.g. January 1 observed on December 31).
+func (r HolidayRule) On(year int) (Holiday, bool) {
+	if (r.from != 0 && year < r.from) || (r.to != 0 && year > r.to) || r.nominal == nil {
+		return Holiday{}, false
+	}
+	d, ok := r.nominal(year)
+	if !ok || !d.IsValid() {
+		return Holiday{}, false
+	}
+	h := Holiday{Date: d, Name: r.Name}
+	if r.adjust != nil {
+		h.Date = r.adjust(d)
+		h.Observed = h.Date != d
+	}
+	return h, true
+}
+
+// MoveToMonday moves a holiday to the following Monday unless it already is one.
+func MoveToMonday(d Date) Date {
+	return d.AddDays((8 - int(d.Weekday())) % 7)
+}
+
+// MoveToNearestMonday moves Tuesday and Wednesday holidays to the previous
+// Monday and Thursday and Friday ones to the next Monday (Argentina's
+// movable holidays). Weekend and Monday holidays are kept.
+func MoveToNearestMonday(d Date) Date {
+	switch d.Weekday() {
+	case Tuesday, Wednesday:
+		return d.AddDays(1 - int(d.Weekday()))
+	case Thursday, Friday:
+		return d.AddDays(8 - int(d.Weekday()))
+	}
+	return d
+}
+
+// ObserveWeekend observes Saturday holidays on Friday and Sunday ones on Monday (US federal rule).
+func ObserveWeekend(d Date) Date {
+	switch d.Weekday() {
+	case Saturday:
+		return d.AddDays(-1)
+	case Sunday:
+		return d.AddDays(1)
+	}
+	return d
+}
+
+// ObserveSunday observes Sunday holidays on the following Monday.
+func ObserveSunday(d Date) Date {
+	if d.Weekday() == Sunday {
+		return d.AddDays(1)
+	}
+	return d
+}
+
+// Easter returns Easter Sunday of a Gregorian year (anonymous Gregorian computus).
+func Easter(year int) Date {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Date{Year: year, Month: Month(month), Day: day}
+}
+
+// nthWeekday returns the nth weekday of a month (negative n counts from the end).
+func nthWeekday(year int, month Month, weekday DayOfWeek, n int) (Date, bool) {
+	if n == 0 {
+		return Date{}, false
+	}
+	if n > 0 {
+		first := Date{Year: year, Month: month, Day: 1}
+		d := first.AddDays(int(weekday.Add(-int(first.Weekday()))) + (n-1)*7)
+		return d, d.Month == month
+	}
+	last := Date{Year: year, Month: month, Day: daysInMonth(year, int(month))}
+	d := last.AddDays(-int(last.Weekday().Add(-int(weekday))) + (n+1)*7)
+	return d, d.Month == month
+}
+
+// HolidayRules generates holidays from a set of rules and caches each year.
+// It implements HolidayCalendar, so it can feed a BusinessCalendar.
+type HolidayRules struct {
+	rules []HolidayRule
+	mu    sync.Mutex
+	years map[int][]Holiday
+}
+
+// NewHolidayRules builds a holiday engine from rules.
+func NewHolidayRules(rules ...HolidayRule) *HolidayRules {
+	return &HolidayRules{rules: rules, years: map[int][]Holiday{}}
+}
+
+// Year returns the holidays observed in year, sorted by date. Rules of the
+// adjacent years are evaluated too, so holidays moved across the year
+// boundary are reported in the year they are observed.
+func (h *HolidayRules) Year(year int) []Holiday {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if list, ok := h.years[year]; ok {
+		return append([]Holiday(nil), list...)
+	}
+	var list []Holiday
+	for y := year - 1; y <= year+1; y++ {
+		for _, r := range h.rules {
+			if hd, ok := r.On(y); ok && hd.Date.Year == year {
+				list = insertHoliday(list, hd)
+			}
+		}
+	}
+	h.years[year] = list
+	return append([]Holiday(nil), list...)
+}
+
+// Between returns the holidays observed from..to inclusive, sorted by date.
+func (h *HolidayRules) Between(from, to Date) []Holiday {
+	var out []Holiday
+	for y := from.Year; y <= to.Year; y++ {
+		for _, hd := range h.Year(y) {
+			if !hd.Date.Before(from) && !hd.Date.After(to) {
+				out = append(out, hd)
+			}
+		}
+	}
+	return out
+}
+
+// Lookup returns the first holiday observed on d.
+func (h *HolidayRules) Lookup(d Date) (Holiday, bool) {
+	for _, hd := range h.Year(d.Year) {
+		if hd.Date == d {
+			return hd, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// IsHoliday reports whether a holiday is observed on d.
+func (h *HolidayRules) IsHoliday(d Date) bool {
+	_, ok := h.Lookup(d)
+	return ok
+}
+
+// insertHoliday inserts hd keeping list sorted by date (stable for equal dates).
+func insertHoliday(list []Holiday, hd Holiday) []Holiday {
+	i := len(list)
+	for i > 0 && list[i-1].Date.After(hd.Date) {
+		i--
+	}
+	list = append(list, Holiday{})
+	copy(list[i+1:], list[i:])
+	list[i] = hd
+	return list
+}
+
+// CountryHolidays returns the national holiday rules for an ISO 3166 country
+// code: CL, AR, MX, ES or US.
+func CountryHolidays(code string) (*HolidayRules, error) {
+	switch ToUpper(code) {
+	case "CL":
+		return ChileHolidays(), nil
+	case "AR":
+		return ArgentinaHolidays(), nil
+	case "MX":
+		return MexicoHolidays(), nil
+	case "ES":
+		return SpainHolidays(), nil
+	case "US":
+		return USHolidays(), nil
+	}
+	return nil, Errf("no holiday rules for country: %s", code)
+}
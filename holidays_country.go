@@ -0,0 +1,168 @@
+package time
+
+// National holiday rule sets. Regional holidays (Spanish autonomous
+// communities, US state holidays, Chilean regional days) are not included;
+// add them with NewHolidayRules and the rule constructors.
+
+// ChileHolidays returns Chile's national holidays, including the movable
+// holidays of Law 19.668 and the winter solstice holiday (from 2021).
+func ChileHolidays() *HolidayRules {
+	return NewHolidayRules(
+		FixedHoliday("Año Nuevo", January, 1),
+		EasterHoliday("Viernes Santo", -2),
+		EasterHoliday("Sábado Santo", -1),
+		FixedHoliday("Día del Trabajo", May, 1),
+		FixedHoliday("Día de las Glorias Navales", May, 21),
+		CustomHoliday("Día Nacional de los Pueblos Indígenas", chileSolsticeDay).Years(2021, 0),
+		FixedHoliday("San Pedro y San Pablo", June, 29).Observe(chileMonday),
+		FixedHoliday("Día de la Virgen del Carmen", July, 16),
+		FixedHoliday("Asunción de la Virgen", August, 15),
+		CustomHoliday("Feriado de Fiestas Patrias", chileSeptemberBridge).Years(2017, 0),
+		FixedHoliday("Independencia Nacional", September, 18),
+		FixedHoliday("Día de las Glorias del Ejército", September, 19),
+		FixedHoliday("Encuentro de Dos Mundos", October, 12).Observe(chileMonday),
+		CustomHoliday("Día de las Iglesias Evangélicas y Protestantes", chileReformationDay).Years(2008, 0),
+		FixedHoliday("Día de Todos los Santos", November, 1),
+		FixedHoliday("Inmaculada Concepción", December, 8),
+		FixedHoliday("Navidad", December, 25),
+	)
+}
+
+// ArgentinaHolidays returns Argentina's national holidays (Law 27.399),
+// without the occasional "puente" bridge days set by decree each year.
+func ArgentinaHolidays() *HolidayRules {
+	return NewHolidayRules(
+		FixedHoliday("Año Nuevo", January, 1),
+		EasterHoliday("Carnaval", -48),
+		EasterHoliday("Carnaval", -47),
+		FixedHoliday("Día Nacional de la Memoria por la Verdad y la Justicia", March, 24),
+		FixedHoliday("Día del Veterano y de los Caídos en la Guerra de Malvinas", April, 2),
+		EasterHoliday("Viernes Santo", -2),
+		FixedHoliday("Día del Trabajador", May, 1),
+		FixedHoliday("Día de la Revolución de Mayo", May, 25),
+		FixedHoliday("Paso a la Inmortalidad del General Martín Miguel de Güemes", June, 17).Observe(MoveToNearestMonday),
+		FixedHoliday("Paso a la Inmortalidad del General Manuel Belgrano", June, 20),
+		FixedHoliday("Día de la Independencia", July, 9),
+		FixedHoliday("Paso a la Inmortalidad del General José de San Martín", August, 17).Observe(MoveToNearestMonday),
+		FixedHoliday("Día del Respeto a la Diversidad Cultural", October, 12).Observe(MoveToNearestMonday),
+		FixedHoliday("Día de la Soberanía Nacional", November, 20).Observe(MoveToNearestMonday),
+		FixedHoliday("Inmaculada Concepción de María", December, 8),
+		FixedHoliday("Navidad", December, 25),
+	)
+}
+
+// MexicoHolidays returns Mexico's statutory rest days (Ley Federal del
+// Trabajo, art. 74), including the presidential inauguration day.
+func MexicoHolidays() *HolidayRules {
+	return NewHolidayRules(
+		FixedHoliday("Año Nuevo", January, 1),
+		NthWeekdayHoliday("Día de la Constitución", February, Monday, 1).Years(2006, 0),
+		NthWeekdayHoliday("Natalicio de Benito Juárez", March, Monday, 3).Years(2006, 0),
+		FixedHoliday("Día del Trabajo", May, 1),
+		FixedHoliday("Día de la Independencia", September, 16),
+		CustomHoliday("Transmisión del Poder Ejecutivo Federal", mexicoInauguration),
+		NthWeekdayHoliday("Día de la Revolución", November, Monday, 3).Years(2006, 0),
+		FixedHoliday("Navidad", December, 25),
+	)
+}
+
+// SpainHolidays returns Spain's national holidays. Sunday substitution is
+// decided by each autonomous community and is not applied.
+func SpainHolidays() *HolidayRules {
+	return NewHolidayRules(
+		FixedHoliday("Año Nuevo", January, 1),
+		FixedHoliday("Epifanía del Señor", January, 6),
+		EasterHoliday("Viernes Santo", -2),
+		FixedHoliday("Fiesta del Trabajo", May, 1),
+		FixedHoliday("Asunción de la Virgen", August, 15),
+		FixedHoliday("Fiesta Nacional de España", October, 12),
+		FixedHoliday("Todos los Santos", November, 1),
+		FixedHoliday("Día de la Constitución Española", December, 6),
+		FixedHoliday("Inmaculada Concepción", December, 8),
+		FixedHoliday("Natividad del Señor", December, 25),
+	)
+}
+
+// USHolidays returns the US federal holidays with the weekend observance rule.
+func USHolidays() *HolidayRules {
+	return NewHolidayRules(
+		FixedHoliday("New Year's Day", January, 1).Observe(ObserveWeekend),
+		NthWeekdayHoliday("Martin Luther King Jr. Day", January, Monday, 3).Years(1986, 0),
+		NthWeekdayHoliday("Washington's Birthday", February, Monday, 3),
+		NthWeekdayHoliday("Memorial Day", May, Monday, -1),
+		FixedHoliday("Juneteenth National Independence Day", June, 19).Observe(ObserveWeekend).Years(2021, 0),
+		FixedHoliday("Independence Day", July, 4).Observe(ObserveWeekend),
+		NthWeekdayHoliday("Labor Day", September, Monday, 1),
+		NthWeekdayHoliday("Columbus Day", October, Monday, 2),
+		FixedHoliday("Veterans Day", November, 11).Observe(ObserveWeekend),
+		NthWeekdayHoliday("Thanksgiving Day", November, Thursday, 4),
+		FixedHoliday("Christmas Day", December, 25).Observe(ObserveWeekend),
+	)
+}
+
+// chileMonday applies Law 19.668: Tuesday to Thursday move to that week's
+// Monday and Friday to the next Monday.
+func chileMonday(d Date) Date {
+	switch d.Weekday() {
+	case Tuesday, Wednesday, Thursday:
+		return d.AddDays(1 - int(d.Weekday()))
+	case Friday:
+		return d.AddDays(3)
+	}
+	return d
+}
+
+// chileReformationDay moves October 31 to the previous Friday when it is a
+// Tuesday and to the next Friday when it is a Wednesday (Law 20.299).
+func chileReformationDay(year int) (Date, bool) {
+	d := Date{Year: year, Month: October, Day: 31}
+	switch d.Weekday() {
+	case Tuesday:
+		return d.AddDays(-4), true
+	case Wednesday:
+		return d.AddDays(2), true
+	}
+	return d, true
+}
+
+// chileSeptemberBridge adds September 17 when it is a Monday and September
+// 20 when it is a Friday (Law 20.983).
+func chileSeptemberBridge(year int) (Date, bool) {
+	d := Date{Year: year, Month: September, Day: 18}
+	switch d.Weekday() {
+	case Tuesday:
+		return d.AddDays(-1), true
+	case Wednesday:
+		return d.AddDays(2), true
+	}
+	return Date{}, false
+}
+
+// chileSolsticeDay returns the day of the June solstice in continental
+// Chile (UTC-4). The 2021 date was fixed by law.
+func chileSolsticeDay(year int) (Date, bool) {
+	if year == 2021 {
+		return Date{Year: 2021, Month: June, Day: 21}, true
+	}
+	return dateFromDays(floorDiv(juneSolstice(year)-4*secondsPerHour, secondsPerDay)), true
+}
+
+// juneSolstice approximates the June solstice in Unix seconds with Meeus'
+// mean formula (valid 1000-3000, within minutes of the true instant).
+func juneSolstice(year int) int64 {
+	y := float64(year-2000) / 1000
+	jde := 2451716.56767 + 365241.62603*y + 0.00325*y*y + 0.00888*y*y*y - 0.00030*y*y*y*y
+	return int64((jde - 2440587.5) * secondsPerDay)
+}
+
+// mexicoInauguration is October 1 every six years from 2024 and December 1
+// in the earlier inauguration years.
+func mexicoInauguration(year int) (Date, bool) {
+	if floorMod(int64(year-2024), 6) != 0 {
+		return Date{}, false
+	}
+	if year >= 2024 {
+		return Date{Year: year, Month: October, Day: 1}, true
+	}
+	return Date{Year: year, Month: December, Day: 1}, true
+}
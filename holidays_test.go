@@ -0,0 +1,95 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test the holiday rule engine and country rule sets
+func HolidaysShared(t *testing.T) {
+	for year, want := range map[int]string{2000: "2000-04-23", 2019: "2019-04-21", 2024: "2024-03-31", 2025: "2025-04-20", 2038: "2038-04-25"} {
+		if got := time.Easter(year).String(); got != want {
+			t.Errorf("Easter(%d) = %s; want %s", year, got, want)
+		}
+	}
+
+	dates := func(list []time.Holiday) []string {
+		out := make([]string, len(list))
+		for i, h := range list {
+			out[i] = h.Date.String()
+		}
+		return out
+	}
+	check := func(name string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s = %v; want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v; want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	check("US 2022", dates(time.USHolidays().Year(2022)),
+		"2022-01-17", "2022-02-21", "2022-05-30", "2022-06-20", "2022-07-04",
+		"2022-09-05", "2022-10-10", "2022-11-11", "2022-11-24", "2022-12-26")
+	check("CL 2023", dates(time.ChileHolidays().Year(2023)),
+		"2023-01-01", "2023-04-07", "2023-04-08", "2023-05-01", "2023-05-21", "2023-06-21",
+		"2023-06-26", "2023-07-16", "2023-08-15", "2023-09-18", "2023-09-19", "2023-10-09",
+		"2023-10-27", "2023-11-01", "2023-12-08", "2023-12-25")
+	check("AR 2024", dates(time.ArgentinaHolidays().Year(2024)),
+		"2024-01-01", "2024-02-12", "2024-02-13", "2024-03-24", "2024-03-29", "2024-04-02",
+		"2024-05-01", "2024-05-25", "2024-06-17", "2024-06-20", "2024-07-09", "2024-08-17",
+		"2024-10-12", "2024-11-18", "2024-12-08", "2024-12-25")
+	check("MX 2024", dates(time.MexicoHolidays().Year(2024)),
+		"2024-01-01", "2024-02-05", "2024-03-18", "2024-05-01", "2024-09-16", "2024-10-01",
+		"2024-11-18", "2024-12-25")
+	check("ES 2025", dates(time.SpainHolidays().Year(2025)),
+		"2025-01-01", "2025-01-06", "2025-04-18", "2025-05-01", "2025-08-15", "2025-10-12",
+		"2025-11-01", "2025-12-06", "2025-12-08", "2025-12-25")
+
+	cl := time.ChileHolidays()
+	var solstice []string
+	for y := 2022; y <= 2030; y++ {
+		for _, h := range cl.Year(y) {
+			if h.Date.Month == time.June && h.Date.Day < 25 {
+				solstice = append(solstice, h.Date.String())
+			}
+		}
+	}
+	check("CL solstice", solstice, "2022-06-21", "2023-06-21", "2024-06-20", "2025-06-20",
+		"2026-06-21", "2027-06-21", "2028-06-20", "2029-06-20", "2030-06-21")
+	check("CL Sep 2018", dates(cl.Between(time.Date{Year: 2018, Month: 9, Day: 1}, time.Date{Year: 2018, Month: 9, Day: 30})),
+		"2018-09-17", "2018-09-18", "2018-09-19")
+
+	// New Year's Day 2022 (Saturday) is observed on Friday 2021-12-31
+	us := time.USHolidays()
+	if h, ok := us.Lookup(time.Date{Year: 2021, Month: 12, Day: 31}); !ok || h.Name != "New Year's Day" || !h.Observed {
+		t.Errorf("Lookup(2021-12-31) = %+v, %v", h, ok)
+	}
+
+	rules := time.NewHolidayRules(
+		time.NthWeekdayHoliday("Last Friday", time.June, time.Friday, -1),
+		time.FixedHoliday("Founders", time.March, 3).Observe(time.MoveToMonday).Years(2020, 2022),
+		time.FixedHoliday("Sunday rest", time.August, 4).Observe(time.ObserveSunday),
+	)
+	check("custom 2024", dates(rules.Year(2024)), "2024-06-28", "2024-08-05")
+	check("custom 2021", dates(rules.Year(2021)), "2021-03-08", "2021-06-25", "2021-08-04")
+
+	bc := time.BusinessCalendar{Holidays: us, Location: time.UTC}
+	christmas := time.Date{Year: 2024, Month: 12, Day: 24}.UnixNanoUTC()
+	if next, _ := bc.NextBusinessDay(christmas); time.DateOfUTC(next).String() != "2024-12-26" {
+		t.Errorf("NextBusinessDay(Christmas Eve) = %s; want 2024-12-26", time.DateOfUTC(next))
+	}
+	if _, err := time.CountryHolidays("cl"); err != nil {
+		t.Error(err)
+	}
+	if _, err := time.CountryHolidays("XX"); err == nil {
+		t.Error("CountryHolidays(XX) should return error")
+	}
+}
@@ -0,0 +1,361 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Param is an iCalendar property parameter such as TZID=America/Santiago.
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property is an iCalendar content line. Value is kept raw (not unescaped);
+// use UnescapeText / EscapeText for TEXT values.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param returns the value of the named parameter, or "".
+func (p Property) Param(name string) string {
+	for _, pr := range p.Params {
+		if pr.Name == name {
+			return pr.Value
+		}
+	}
+	return ""
+}
+
+// Component is an iCalendar component (VCALENDAR, VEVENT, VALARM, ...) with
+// its properties and sub-components in document order.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// Get returns the first property with the given name.
+func (c *Component) Get(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// Value returns the raw value of the first property with the given name, or "".
+func (c *Component) Value(name string) string {
+	p, _ := c.Get(name)
+	return p.Value
+}
+
+// Add appends a property.
+func (c *Component) Add(name, value string, params ...Param) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// Children returns the direct sub-components with the given name.
+func (c *Component) Children(name string) []*Component {
+	var out []*Component
+	for _, sub := range c.Components {
+		if sub.Name == name {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+// ParseComponent parses iCalendar data into its outermost component
+// (usually VCALENDAR). Folded lines are joined and CRLF or LF endings are accepted.
+func ParseComponent(data string) (*Component, error) {
+	var stack []*Component
+	var root *Component
+	for _, line := range unfoldLines(data) {
+		if line == "" {
+			continue
+		}
+		prop, err := parseContentLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch prop.Name {
+		case "BEGIN":
+			c := &Component{Name: ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			} else if root != nil {
+				return nil, Errf("iCalendar: more than one top-level component")
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != ToUpper(prop.Value) {
+				return nil, Errf("iCalendar: unexpected END:%s", prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, Errf("iCalendar: property outside a component: %s", prop.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+	}
+	if root == nil {
+		return nil, Errf("iCalendar: no component found")
+	}
+	if len(stack) > 0 {
+		return nil, Errf("iCalendar: missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// String encodes c as iCalendar text with CRLF line endings and lines
+// folded at 75 octets.
+func (c *Component) String() string {
+	var buf []byte
+	buf = c.appendTo(buf)
+	return string(buf)
+}
+
+func (c *Component) appendTo(buf []byte) []byte {
+	buf = appendFolded(buf, "BEGIN:"+c.Name)
+	for _, p := range c.Properties {
+		line := p.Name
+		for _, pr := range p.Params {
+			line += ";" + pr.Name + "=" + quoteParam(pr.Value)
+		}
+		buf = appendFolded(buf, line+":"+p.Value)
+	}
+	for _, sub := range c.Components {
+		buf = sub.appendTo(buf)
+	}
+	return appendFolded(buf, "END:"+c.Name)
+}
+
+// parseContentLine parses "NAME;PARAM=VALUE;...:value". Names are
+// upper-cased and quoted parameter values may contain ':', ';' and ','.
+func parseContentLine(line string) (Property, error) {
+	var p Property
+	var fields []string
+	start, quoted := 0, false
+	i := 0
+	for ; i < len(line); i++ {
+		c := line[i]
+		if c == '"' {
+			quoted = !quoted
+		} else if !quoted && (c == ';' || c == ':') {
+			fields = append(fields, line[start:i])
+			start = i + 1
+			if c == ':' {
+				break
+			}
+		}
+	}
+	if i == len(line) || fields[0] == "" {
+		return p, Errf("invalid content line: %s", line)
+	}
+	p.Name, p.Value = ToUpper(fields[0]), line[i+1:]
+	for _, f := range fields[1:] {
+		k, v, ok := cutByte(f, '=')
+		if !ok || k == "" {
+			return p, Errf("invalid parameter: %s", f)
+		}
+		if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+			v = v[1 : len(v)-1]
+		}
+		p.Params = append(p.Params, Param{Name: ToUpper(k), Value: v})
+	}
+	return p, nil
+}
+
+// cutByte slices s around the first c.
+func cutByte(s string, c byte) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// unfoldLines splits data into content lines, joining continuation lines
+// that start with a space or a tab.
+func unfoldLines(data string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i <= len(data); i++ {
+		if i < len(data) && data[i] != '\n' {
+			continue
+		}
+		line := data[start:i]
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+		start = i + 1
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// appendFolded appends line and CRLF, folding it so no physical line
+// exceeds 75 octets and UTF-8 sequences are never split.
+func appendFolded(buf []byte, line string) []byte {
+	const limit = 75
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf = append(buf, line[:cut]...)
+		buf = append(buf, "\r\n "...)
+		line = line[cut:]
+		width = limit - 1
+	}
+	buf = append(buf, line...)
+	return append(buf, "\r\n"...)
+}
+
+// quoteParam quotes a parameter value containing ':', ';' or ','.
+func quoteParam(v string) string {
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; c == ':' || c == ';' || c == ',' {
+			return `"` + v + `"`
+		}
+	}
+	return v
+}
+
+// EscapeText escapes a TEXT value: backslash, semicolon, comma and newlines.
+func EscapeText(s string) string {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', ';', ',':
+			buf = append(buf, '\\', c)
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return string(buf)
+}
+
+// UnescapeText reverses EscapeText.
+func UnescapeText(s string) string {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			c = s[i]
+			if c == 'n' || c == 'N' {
+				c = '\n'
+			}
+		}
+		buf = append(buf, c)
+	}
+	return string(buf)
+}
+
+// parseICalDuration parses an RFC 5545 duration such as "-PT15M" or "P1DT2H" into nanoseconds.
+func parseICalDuration(s string) (int64, error) {
+	days, seconds, err := splitICalDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return (days*secondsPerDay + seconds) * nanosPerSecond, nil
+}
+
+// splitICalDuration parses an RFC 5545 duration into its nominal days
+// (weeks and days, added in wall-clock time) and exact seconds, both signed.
+func splitICalDuration(s string) (days, seconds int64, err error) {
+	orig := s
+	sign := int64(1)
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if len(s) < 3 || s[0] != 'P' {
+		return 0, 0, Errf("invalid duration: %s", orig)
+	}
+	inTime, digits := false, 0
+	n := 0
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			digits++
+			continue
+		case c == 'T' && !inTime && digits == 0:
+			inTime = true
+			continue
+		}
+		if digits == 0 {
+			return 0, 0, Errf("invalid duration: %s", orig)
+		}
+		switch {
+		case c == 'W' && !inTime:
+			days += int64(n) * 7
+		case c == 'D' && !inTime:
+			days += int64(n)
+		case c == 'H' && inTime:
+			seconds += int64(n) * secondsPerHour
+		case c == 'M' && inTime:
+			seconds += int64(n) * secondsPerMinute
+		case c == 'S' && inTime:
+			seconds += int64(n)
+		default:
+			return 0, 0, Errf("invalid duration: %s", orig)
+		}
+		n, digits = 0, 0
+	}
+	if digits != 0 {
+		return 0, 0, Errf("invalid duration: %s", orig)
+	}
+	return sign * days, sign * seconds, nil
+}
+
+// formatICalDuration formats nanoseconds as an RFC 5545 duration (whole seconds).
+func formatICalDuration(nanos int64) string {
+	buf := []byte{}
+	if nanos < 0 {
+		buf = append(buf, '-')
+		nanos = -nanos
+	}
+	sec := nanos / nanosPerSecond
+	buf = append(buf, 'P')
+	if d := sec / secondsPerDay; d > 0 {
+		buf = append(buf, Sprintf("%dD", d)...)
+		sec %= secondsPerDay
+	}
+	if sec > 0 || len(buf) <= 2 {
+		buf = append(buf, 'T')
+		h, m, s := sec/secondsPerHour, sec%secondsPerHour/secondsPerMinute, sec%secondsPerMinute
+		if h > 0 {
+			buf = append(buf, Sprintf("%dH", h)...)
+		}
+		if m > 0 {
+			buf = append(buf, Sprintf("%dM", m)...)
+		}
+		if s > 0 || (h == 0 && m == 0) {
+			buf = append(buf, Sprintf("%dS", s)...)
+		}
+	}
+	return string(buf)
+}
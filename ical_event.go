@@ -0,0 +1,382 @@
+package time
+
+import (
+	"sync/atomic"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Calendar is an iCalendar VCALENDAR with its events. Other components
+// (VTODO, VJOURNAL, ...) and calendar properties are preserved verbatim.
+type Calendar struct {
+	ProdID     string
+	Events     []Event
+	Extra      []Property   // calendar properties other than VERSION and PRODID
+	Components []*Component // components other than VEVENT and VTIMEZONE
+}
+
+// Event is a VEVENT. Times are UnixNano instants; Zone tells how they are
+// written: a TZID with its VTIMEZONE for named and fixed-offset zones, a
+// "Z" suffix for UTC and floating local times for Local (nil). All-day
+// events use DATE values and store the UTC midnight of each date, with End
+// exclusive.
+type Event struct {
+	UID         string // generated when writing an empty value
+	Summary     string
+	Description string
+	Location    string // LOCATION text
+	Start       int64
+	End         int64 // 0 when the event has neither DTEND nor DURATION
+	AllDay      bool
+	Zone        *Location
+	Stamp       int64 // DTSTAMP; Now() when writing a zero value
+	Rule        *RRule
+	RDates      []int64
+	ExDates     []int64
+	Alarms      []Alarm
+	Extra       []Property // other properties, preserved verbatim
+}
+
+// Alarm is a VALARM triggered relative to the event start.
+type Alarm struct {
+	Action      string // DISPLAY, AUDIO or EMAIL
+	Trigger     int64  // nanoseconds from the start; negative is before
+	Description string
+	Extra       []Property // other properties (including absolute or END-related TRIGGERs)
+}
+
+// Recurrence returns the event's recurrence set (DTSTART, RRULE, RDATE, EXDATE).
+func (e Event) Recurrence() *Recurrence {
+	loc := e.Zone
+	if e.AllDay {
+		loc = UTC
+	}
+	return &Recurrence{Start: DateTimeOf(e.Start, loc), Location: loc, Rule: e.Rule, RDates: e.RDates, ExDates: e.ExDates}
+}
+
+// ParseCalendar reads iCalendar data. TZIDs are resolved with the
+// calendar's own VTIMEZONE definitions first, so exported offsets read back
+// unchanged, and as IANA names when the calendar does not define them.
+func ParseCalendar(data string) (*Calendar, error) {
+	root, err := ParseComponent(data)
+	if err != nil {
+		return nil, err
+	}
+	if root.Name != "VCALENDAR" {
+		return nil, Errf("iCalendar: expected VCALENDAR, got %s", root.Name)
+	}
+	cal := &Calendar{}
+	for _, p := range root.Properties {
+		switch p.Name {
+		case "PRODID":
+			cal.ProdID = p.Value
+		case "VERSION":
+		default:
+			cal.Extra = append(cal.Extra, p)
+		}
+	}
+	zones := map[string]*Location{}
+	for _, c := range root.Children("VTIMEZONE") {
+		if loc, err := LocationFromVTimezone(c); err == nil {
+			zones[loc.name] = loc
+		}
+	}
+	for _, c := range root.Components {
+		switch c.Name {
+		case "VEVENT":
+			ev, err := parseEvent(c, zones)
+			if err != nil {
+				return nil, err
+			}
+			cal.Events = append(cal.Events, ev)
+		case "VTIMEZONE":
+		default:
+			cal.Components = append(cal.Components, c)
+		}
+	}
+	return cal, nil
+}
+
+// icalTime is a decoded DATE or DATE-TIME property.
+type icalTime struct {
+	nano     int64
+	loc      *Location
+	dateOnly bool
+}
+
+// parseICalTimes decodes a (possibly comma-separated) DATE / DATE-TIME property.
+func parseICalTimes(p Property, zones map[string]*Location) ([]icalTime, error) {
+	loc := Local
+	if tzid := p.Param("TZID"); tzid != "" {
+		if loc = zones[tzid]; loc == nil {
+			var err error
+			if loc, err = LoadLocation(tzid); err != nil {
+				return nil, Errf("iCalendar: unknown TZID %s", tzid)
+			}
+		}
+	}
+	var out []icalTime
+	for _, v := range Convert(p.Value).Split(",") {
+		dt, utc, dateOnly, err := parseICalDateTime(v)
+		if err != nil {
+			return nil, err
+		}
+		t := icalTime{loc: loc, dateOnly: dateOnly}
+		switch {
+		case dateOnly:
+			t.nano, t.loc = dt.UnixNanoOffset(0), UTC
+		case utc:
+			t.nano, t.loc = dt.UnixNanoOffset(0), UTC
+		default:
+			t.nano, _ = dt.UnixNanoIn(loc, Compatible)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func parseEvent(c *Component, zones map[string]*Location) (Event, error) {
+	var ev Event
+	var durDays, durSeconds int64
+	hasDuration := false
+	for _, p := range c.Properties {
+		var err error
+		switch p.Name {
+		case "UID":
+			ev.UID = p.Value
+		case "SUMMARY":
+			ev.Summary = UnescapeText(p.Value)
+		case "DESCRIPTION":
+			ev.Description = UnescapeText(p.Value)
+		case "LOCATION":
+			ev.Location = UnescapeText(p.Value)
+		case "DTSTART", "DTEND", "DTSTAMP":
+			var ts []icalTime
+			if ts, err = parseICalTimes(p, zones); err == nil {
+				switch p.Name {
+				case "DTSTART":
+					ev.Start, ev.Zone, ev.AllDay = ts[0].nano, ts[0].loc, ts[0].dateOnly
+					if ev.Zone == Local {
+						ev.Zone = nil
+					}
+				case "DTEND":
+					ev.End = ts[0].nano
+				default:
+					ev.Stamp = ts[0].nano
+				}
+			}
+		case "DURATION":
+			durDays, durSeconds, err = splitICalDuration(p.Value)
+			hasDuration = true
+		case "RRULE":
+			var rule RRule
+			if rule, err = ParseRRule(p.Value); err == nil {
+				ev.Rule = &rule
+			}
+		case "RDATE", "EXDATE":
+			if p.Param("VALUE") == "PERIOD" {
+				ev.Extra = append(ev.Extra, p)
+				continue
+			}
+			var ts []icalTime
+			if ts, err = parseICalTimes(p, zones); err == nil {
+				for _, t := range ts {
+					if p.Name == "RDATE" {
+						ev.RDates = append(ev.RDates, t.nano)
+					} else {
+						ev.ExDates = append(ev.ExDates, t.nano)
+					}
+				}
+			}
+		default:
+			ev.Extra = append(ev.Extra, p)
+		}
+		if err != nil {
+			return ev, Errf("iCalendar: %s: %v", p.Name, err)
+		}
+	}
+	if hasDuration && ev.End == 0 {
+		// Days and weeks are nominal: P1D ends at the same wall time the
+		// next day, even across a DST change.
+		loc := ev.Zone
+		if ev.AllDay {
+			loc = UTC
+		}
+		end, _ := DateTimeOf(ev.Start, loc).AddDays(int(durDays)).UnixNanoIn(loc, Compatible)
+		ev.End = end + durSeconds*nanosPerSecond
+	}
+	for _, a := range c.Children("VALARM") {
+		ev.Alarms = append(ev.Alarms, parseAlarm(a))
+	}
+	return ev, nil
+}
+
+func parseAlarm(c *Component) Alarm {
+	var a Alarm
+	for _, p := range c.Properties {
+		switch p.Name {
+		case "ACTION":
+			a.Action = p.Value
+		case "DESCRIPTION":
+			a.Description = UnescapeText(p.Value)
+		case "TRIGGER":
+			if d, err := parseICalDuration(p.Value); err == nil && p.Param("VALUE") == "" && p.Param("RELATED") != "END" {
+				a.Trigger = d
+				continue
+			}
+			a.Extra = append(a.Extra, p)
+		default:
+			a.Extra = append(a.Extra, p)
+		}
+	}
+	return a
+}
+
+// String encodes the calendar as an .ics document, adding a VTIMEZONE for
+// every named zone used by the events.
+func (cal *Calendar) String() string {
+	return cal.Component().String()
+}
+
+// Component converts the calendar into its generic component tree.
+func (cal *Calendar) Component() *Component {
+	root := &Component{Name: "VCALENDAR"}
+	root.Add("VERSION", "2.0")
+	prodID := cal.ProdID
+	if prodID == "" {
+		prodID = "-//tinywasm//time//EN"
+	}
+	root.Add("PRODID", prodID)
+	root.Properties = append(root.Properties, cal.Extra...)
+
+	type span struct {
+		loc      *Location
+		from, to int64
+	}
+	var zones []span
+	for _, ev := range cal.Events {
+		if !tzidZone(ev.Zone) || ev.AllDay {
+			continue
+		}
+		to := max(ev.End, ev.Start)
+		if ev.Rule != nil || len(ev.RDates) > 0 {
+			to = max(to, ev.Start+10*365*secondsPerDay*nanosPerSecond)
+		}
+		found := false
+		for i := range zones {
+			if icalTZID(zones[i].loc) == icalTZID(ev.Zone) {
+				zones[i].from, zones[i].to = min(zones[i].from, ev.Start), max(zones[i].to, to)
+				found = true
+			}
+		}
+		if !found {
+			zones = append(zones, span{loc: ev.Zone, from: ev.Start, to: to})
+		}
+	}
+	for _, z := range zones {
+		root.Components = append(root.Components, VTimezone(z.loc, z.from-366*secondsPerDay*nanosPerSecond, z.to))
+	}
+	for _, ev := range cal.Events {
+		root.Components = append(root.Components, ev.Component())
+	}
+	root.Components = append(root.Components, cal.Components...)
+	return root
+}
+
+// namedZone reports whether loc is written with a TZID parameter.
+func namedZone(loc *Location) bool {
+	return loc != nil && (loc.kind == locationZone || loc.kind == locationRules)
+}
+
+// tzidZone reports whether times in loc are written with a TZID: named
+// zones and fixed offsets other than UTC.
+func tzidZone(loc *Location) bool {
+	return namedZone(loc) || (loc != nil && loc.kind == locationFixed && loc != UTC)
+}
+
+// uidSeq makes generated UIDs unique within the process.
+var uidSeq atomic.Int64
+
+// Component converts the event into a VEVENT. An empty UID, which RFC 5545
+// forbids, is replaced by one built from DTSTAMP and a counter; set UID to
+// keep it stable across exports.
+func (e Event) Component() *Component {
+	c := &Component{Name: "VEVENT"}
+	stamp := e.Stamp
+	if stamp == 0 {
+		stamp = Now()
+	}
+	uid := e.UID
+	if uid == "" {
+		uid = Sprintf("%d-%d@tinywasm-time", stamp, uidSeq.Add(1))
+	}
+	c.Add("UID", uid)
+	c.Add("DTSTAMP", formatICalDateTime(DateTimeOf(stamp, UTC), true))
+	c.Properties = append(c.Properties, e.timeProperty("DTSTART", e.Start))
+	if e.End != 0 {
+		c.Properties = append(c.Properties, e.timeProperty("DTEND", e.End))
+	}
+	if e.Summary != "" {
+		c.Add("SUMMARY", EscapeText(e.Summary))
+	}
+	if e.Description != "" {
+		c.Add("DESCRIPTION", EscapeText(e.Description))
+	}
+	if e.Location != "" {
+		c.Add("LOCATION", EscapeText(e.Location))
+	}
+	if e.Rule != nil {
+		c.Add("RRULE", e.Rule.String())
+	}
+	for _, n := range e.RDates {
+		c.Properties = append(c.Properties, e.timeProperty("RDATE", n))
+	}
+	for _, n := range e.ExDates {
+		c.Properties = append(c.Properties, e.timeProperty("EXDATE", n))
+	}
+	c.Properties = append(c.Properties, e.Extra...)
+	for _, a := range e.Alarms {
+		c.Components = append(c.Components, a.Component())
+	}
+	return c
+}
+
+// timeProperty formats an instant the way the event's DTSTART is written.
+func (e Event) timeProperty(name string, nano int64) Property {
+	p := Property{Name: name}
+	switch {
+	case e.AllDay:
+		p.Params = []Param{{Name: "VALUE", Value: "DATE"}}
+		p.Value = formatICalDate(DateOfUTC(nano))
+	case tzidZone(e.Zone):
+		p.Params = []Param{{Name: "TZID", Value: icalTZID(e.Zone)}}
+		p.Value = formatICalDateTime(DateTimeOf(nano, e.Zone), false)
+	case e.Zone == nil || e.Zone.kind == locationActive:
+		p.Value = formatICalDateTime(DateTimeOf(nano, Local), false)
+	default:
+		p.Value = formatICalDateTime(DateTimeOf(nano, UTC), true)
+	}
+	return p
+}
+
+// Component converts the alarm into a VALARM.
+func (a Alarm) Component() *Component {
+	c := &Component{Name: "VALARM"}
+	action := a.Action
+	if action == "" {
+		action = "DISPLAY"
+	}
+	c.Add("ACTION", action)
+	hasTrigger := false
+	for _, p := range a.Extra {
+		hasTrigger = hasTrigger || p.Name == "TRIGGER"
+	}
+	if !hasTrigger {
+		c.Add("TRIGGER", formatICalDuration(a.Trigger))
+	}
+	if a.Description != "" || action == "DISPLAY" {
+		c.Add("DESCRIPTION", EscapeText(a.Description))
+	}
+	c.Properties = append(c.Properties, a.Extra...)
+	return c
+}
@@ -0,0 +1,223 @@
+package time_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+const icsSample = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Test//EN\r\n" +
+	"X-WR-CALNAME:Team\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Pacific Standard Time\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:16011104T020000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11\r\n" +
+	"TZOFFSETFROM:-0700\r\n" +
+	"TZOFFSETTO:-0800\r\n" +
+	"END:STANDARD\r\n" +
+	"BEGIN:DAYLIGHT\r\n" +
+	"DTSTART:16010311T020000\r\n" +
+	"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3\r\n" +
+	"TZOFFSETFROM:-0800\r\n" +
+	"TZOFFSETTO:-0700\r\n" +
+	"END:DAYLIGHT\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTAMP:20240101T120000Z\r\n" +
+	"DTSTART;TZID=\"Pacific Standard Time\":20240308T090000\r\n" +
+	"DURATION:PT15M\r\n" +
+	"SUMMARY:Standup\\, daily\r\n" +
+	"DESCRIPTION:Line one\\nLine two with a long text that must be folded when wr\r\n" +
+	" itten back\r\n" +
+	"RRULE:FREQ=DAILY;COUNT=5;BYDAY=MO,TU,WE,TH,FR\r\n" +
+	"EXDATE;TZID=\"Pacific Standard Time\":20240312T090000\r\n" +
+	"CATEGORIES:WORK\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER:-PT10M\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@example.com\r\n" +
+	"DTSTAMP:20240101T120000Z\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241226\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+// Test iCalendar parsing, writing, folding, time zones and alarms
+func ICalendarShared(t *testing.T) {
+	cal, err := time.ParseCalendar(icsSample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.ProdID != "-//Example//Test//EN" || len(cal.Events) != 2 || len(cal.Extra) != 1 {
+		t.Fatalf("calendar = %+v", cal)
+	}
+
+	ev := cal.Events[0]
+	if ev.Summary != "Standup, daily" {
+		t.Errorf("Summary = %q", ev.Summary)
+	}
+	if !strings.HasPrefix(ev.Description, "Line one\nLine two") || !strings.HasSuffix(ev.Description, "written back") {
+		t.Errorf("Description = %q", ev.Description)
+	}
+	// 2024-03-08 09:00 PST (-08:00)
+	if got := time.TimeOf(ev.Start).UTC().Format("2006-01-02 15:04"); got != "2024-03-08 17:00" {
+		t.Errorf("Start = %s; want 2024-03-08 17:00 UTC", got)
+	}
+	if ev.End-ev.Start != 15*60*1e9 {
+		t.Errorf("End - Start = %d; want 15 minutes", ev.End-ev.Start)
+	}
+	if ev.Zone.String() != "Pacific Standard Time" {
+		t.Errorf("Zone = %s", ev.Zone)
+	}
+	if len(ev.Alarms) != 1 || ev.Alarms[0].Trigger != -10*60*1e9 || ev.Alarms[0].Description != "Reminder" {
+		t.Errorf("Alarms = %+v", ev.Alarms)
+	}
+	if len(ev.Extra) != 1 || ev.Extra[0].Name != "CATEGORIES" {
+		t.Errorf("Extra = %+v", ev.Extra)
+	}
+
+	// Occurrences follow the custom VTIMEZONE across the March 10 DST change.
+	var got []string
+	for _, nano := range ev.Recurrence().Take(10) {
+		got = append(got, time.TimeOf(nano).UTC().Format("01-02 15:04"))
+	}
+	want := []string{"03-08 17:00", "03-11 16:00", "03-13 16:00", "03-14 16:00"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("occurrences = %v; want %v", got, want)
+	}
+
+	day := cal.Events[1]
+	if !day.AllDay || time.DateOfUTC(day.Start).String() != "2024-12-25" || day.End-day.Start != 86400*1e9 {
+		t.Errorf("all-day event = %+v", day)
+	}
+
+	// Round trip
+	out := cal.String()
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	for _, want := range []string{
+		"VERSION:2.0\r\n",
+		"TZID:Pacific Standard Time\r\n",
+		"DTSTART;TZID=Pacific Standard Time:20240308T090000\r\n",
+		"DTEND;TZID=Pacific Standard Time:20240308T091500\r\n",
+		"SUMMARY:Standup\\, daily\r\n",
+		"RRULE:FREQ=DAILY;COUNT=5;BYDAY=MO,TU,WE,TH,FR\r\n",
+		"TRIGGER:-PT10M\r\n",
+		"DTSTART;VALUE=DATE:20241225\r\n",
+		"X-WR-CALNAME:Team\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	again, err := time.ParseCalendar(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Events) != 2 || again.Events[0].Start != ev.Start || again.Events[0].Description != ev.Description ||
+		len(again.Events[0].ExDates) != 1 || again.Events[0].ExDates[0] != ev.ExDates[0] {
+		t.Errorf("round trip = %+v", again.Events)
+	}
+
+	// Writing an IANA zone generates a VTIMEZONE with its transitions.
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	startDT, err := time.ParseCivilDateTime("2024-03-15T10:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, _ := startDT.UnixNanoIn(santiago, time.Compatible)
+	gen := (&time.Calendar{Events: []time.Event{{
+		UID: "x@example.com", Start: start, End: start + 3600*1e9, Zone: santiago, Stamp: start,
+		Summary: "ñandú; café, 東京 " + strings.Repeat("é", 40),
+	}}}).String()
+	if !strings.Contains(gen, "BEGIN:VTIMEZONE\r\nTZID:America/Santiago\r\n") ||
+		!strings.Contains(gen, "DTSTART;TZID=America/Santiago:20240315T100000\r\n") ||
+		!strings.Contains(gen, "TZOFFSETFROM:-0300\r\nTZOFFSETTO:-0400\r\n") {
+		t.Errorf("generated calendar:\n%s", gen)
+	}
+	parsed, err := time.ParseCalendar(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Events[0].Start != start || parsed.Events[0].Summary != "ñandú; café, 東京 "+strings.Repeat("é", 40) {
+		t.Errorf("parsed = %+v", parsed.Events[0])
+	}
+
+	// UTC events use the Z suffix.
+	utc := (&time.Calendar{Events: []time.Event{{UID: "u", Start: 0, Zone: time.UTC, Stamp: 0}}}).String()
+	if !strings.Contains(utc, "DTSTART:19700101T000000Z\r\n") {
+		t.Errorf("UTC event:\n%s", utc)
+	}
+
+	// Fixed offsets keep their offset through a TZID and a VTIMEZONE.
+	for _, fz := range []struct {
+		loc  *time.Location
+		tzid string
+	}{{time.FixedZone("Clinic", 19800), "Clinic"}, {time.FixedZone("", -10800), "UTC-0300"}, {time.FixedZone("CET", 3600), "CET"}} {
+		ev := time.Event{UID: "f", Start: start, End: start + 3600*1e9, Zone: fz.loc, Stamp: start}
+		fixed := (&time.Calendar{Events: []time.Event{ev}}).String()
+		wantStart := "DTSTART;TZID=" + fz.tzid + ":" + time.TimeOf(start).In(fz.loc).Format("20060102T150405") + "\r\n"
+		if !strings.Contains(fixed, "BEGIN:VTIMEZONE\r\nTZID:"+fz.tzid+"\r\n") || !strings.Contains(fixed, wantStart) {
+			t.Errorf("fixed-offset event:\n%s", fixed)
+		}
+		back, err := time.ParseCalendar(fixed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		summer := start + 120*86400*1e9
+		if got := back.Events[0]; got.Start != start || got.Zone.Offset(summer) != fz.loc.Offset(summer) || got.Zone.String() != fz.tzid {
+			t.Errorf("fixed-offset round trip: start %d, zone %s %d", got.Start, got.Zone, got.Zone.Offset(summer))
+		}
+	}
+
+	// Nominal DURATION days keep the wall time across a DST change.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := time.ParseCalendar("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:d\r\n" +
+		"DTSTART;TZID=America/New_York:20240309T120000\r\nDURATION:P1DT1H\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := time.TimeOf(dst.Events[0].End).In(ny).Format("2006-01-02 15:04 -07:00"); got != "2024-03-10 13:00 -04:00" {
+		t.Errorf("DURATION:P1DT1H end = %s; want 2024-03-10 13:00 -04:00", got)
+	}
+
+	// An empty UID is generated, and unique per event.
+	anon := (&time.Calendar{Events: []time.Event{{Start: 0, Zone: time.UTC}, {Start: 0, Zone: time.UTC}}})
+	back, err := time.ParseCalendar(anon.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uid0, uid1 := back.Events[0].UID, back.Events[1].UID; uid0 == "" || uid1 == "" || uid0 == uid1 {
+		t.Errorf("generated UIDs = %q, %q", uid0, uid1)
+	}
+
+	for _, bad := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=Nowhere/Zone:20240101T000000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nnot a content line\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := time.ParseCalendar(bad); err == nil {
+			t.Errorf("ParseCalendar(%q) should fail", bad)
+		}
+	}
+}
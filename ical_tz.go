@@ -0,0 +1,238 @@
+package time
+
+import (
+	"slices"
+	"sync"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// zoneRules is a timezone defined by offset transitions, such as a
+// VTIMEZONE whose TZID is not an IANA name. Transitions are expanded from
+// the observances on demand, a few years at a time.
+type zoneRules struct {
+	mu          sync.Mutex
+	observances []observance
+	initial     int // offset before the first transition
+	transitions []zoneTransition
+	horizon     int // transitions are computed up to the end of this year
+	source      *Component
+}
+
+type observance struct {
+	rec Recurrence
+	to  int
+}
+
+type zoneTransition struct {
+	at     int64 // Unix seconds
+	offset int
+}
+
+func (z *zoneRules) offsetAt(unixSec int64) int {
+	year := dateFromDays(floorDiv(unixSec, secondsPerDay)).Year
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if year >= z.horizon && z.horizon < maxRecurrenceYear {
+		z.expand(min(year+10, maxRecurrenceYear))
+	}
+	i, found := slices.BinarySearchFunc(z.transitions, unixSec, func(t zoneTransition, sec int64) int {
+		return cmpInt64(t.at, sec)
+	})
+	if found {
+		return z.transitions[i].offset
+	}
+	if i == 0 {
+		return z.initial
+	}
+	return z.transitions[i-1].offset
+}
+
+// expand recomputes the transitions up to the end of horizon.
+func (z *zoneRules) expand(horizon int) {
+	limit := Date{Year: horizon + 1, Month: January, Day: 1}.UnixNanoUTC()
+	z.transitions = z.transitions[:0]
+	for _, o := range z.observances {
+		it := o.rec.Iterator()
+		for {
+			nano, ok := it.Next()
+			if !ok || nano >= limit {
+				break
+			}
+			z.transitions = append(z.transitions, zoneTransition{at: nano / nanosPerSecond, offset: o.to})
+		}
+	}
+	slices.SortFunc(z.transitions, func(a, b zoneTransition) int { return cmpInt64(a.at, b.at) })
+	z.horizon = horizon
+}
+
+// minNanoYear is the first whole year representable as UnixNano.
+const minNanoYear = 1678
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// LocationFromVTimezone builds a Location from a VTIMEZONE component and
+// its STANDARD / DAYLIGHT observances (DTSTART, TZOFFSETFROM, TZOFFSETTO,
+// RRULE and RDATE). The Location is named after the TZID.
+func LocationFromVTimezone(c *Component) (*Location, error) {
+	tzid := c.Value("TZID")
+	if c.Name != "VTIMEZONE" || tzid == "" {
+		return nil, Errf("iCalendar: invalid VTIMEZONE")
+	}
+	z := &zoneRules{source: c}
+	var first DateTime
+	for _, sub := range c.Components {
+		if sub.Name != "STANDARD" && sub.Name != "DAYLIGHT" {
+			continue
+		}
+		from, err1 := parseUTCOffset(sub.Value("TZOFFSETFROM"))
+		to, err2 := parseUTCOffset(sub.Value("TZOFFSETTO"))
+		start, _, _, err3 := parseICalDateTime(sub.Value("DTSTART"))
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, Errf("iCalendar: invalid %s in VTIMEZONE %s", sub.Name, tzid)
+		}
+		rec := Recurrence{Start: start, Location: FixedZone("", from)}
+		for _, p := range sub.Properties {
+			switch p.Name {
+			case "RRULE":
+				rule, err := ParseRRule(p.Value)
+				if err != nil {
+					return nil, err
+				}
+				rec.Rule = &rule
+			case "RDATE":
+				for _, v := range Convert(p.Value).Split(",") {
+					dt, utc, _, err := parseICalDateTime(v)
+					if err != nil {
+						return nil, err
+					}
+					if dt.Date.Year < minNanoYear {
+						continue
+					}
+					if utc {
+						rec.RDates = append(rec.RDates, dt.UnixNanoOffset(0))
+					} else {
+						rec.RDates = append(rec.RDates, dt.UnixNanoOffset(from))
+					}
+				}
+			}
+		}
+		if start.Date.Year < minNanoYear {
+			// Observances often start in 1601, before the UnixNano range:
+			// move DTSTART forward by whole rule intervals.
+			step := 1
+			if rec.Rule != nil && rec.Rule.Interval > 1 {
+				step = rec.Rule.Interval
+			}
+			rec.Start.Date.Year += (minNanoYear - start.Date.Year + step - 1) / step * step
+			rec.Start.Date.Day = min(rec.Start.Date.Day, daysInMonth(rec.Start.Date.Year, int(rec.Start.Date.Month)))
+		}
+		z.observances = append(z.observances, observance{rec: rec, to: to})
+		if len(z.observances) == 1 || start.Before(first) {
+			first, z.initial = start, from
+		}
+	}
+	if len(z.observances) == 0 {
+		return nil, Errf("iCalendar: VTIMEZONE %s has no observances", tzid)
+	}
+	return &Location{name: tzid, kind: locationRules, rules: z}, nil
+}
+
+// VTimezone returns a VTIMEZONE for loc covering the instants from..to
+// (UnixNano). Locations read from a VTIMEZONE return their source; other
+// zones list each offset change found in the range as an observance.
+func VTimezone(loc *Location, from, to int64) *Component {
+	if loc != nil && loc.kind == locationRules && loc.rules.source != nil {
+		return loc.rules.source
+	}
+	c := &Component{Name: "VTIMEZONE"}
+	c.Add("TZID", icalTZID(loc))
+
+	const week = 7 * secondsPerDay
+	start, _ := splitNano(from)
+	end, _ := splitNano(to)
+	prev := int(loc.offsetAt(start))
+	c.Components = append(c.Components, zoneObservance(start, prev, prev))
+	for t := start; t < end; t += week {
+		next := int(loc.offsetAt(t + week))
+		if next == prev {
+			continue
+		}
+		lo, hi := t, t+week // offset changes in (lo, hi]
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if int(loc.offsetAt(mid)) == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		c.Components = append(c.Components, zoneObservance(hi, prev, next))
+		prev = next
+	}
+	return c
+}
+
+// icalTZID returns the TZID written for loc: its name, or "UTC+0530" style
+// for an unnamed fixed offset.
+func icalTZID(loc *Location) string {
+	if loc != nil && loc.kind == locationFixed && loc.name == "" {
+		return "UTC" + formatUTCOffset(loc.offset)
+	}
+	return loc.String()
+}
+
+// zoneObservance builds a STANDARD or DAYLIGHT observance starting at unixSec.
+func zoneObservance(unixSec int64, from, to int) *Component {
+	name := "STANDARD"
+	if to > from {
+		name = "DAYLIGHT"
+	}
+	o := &Component{Name: name}
+	o.Add("DTSTART", formatICalDateTime(dateTimeFromLocal(unixSec+int64(from), 0), false))
+	o.Add("TZOFFSETFROM", formatUTCOffset(from))
+	o.Add("TZOFFSETTO", formatUTCOffset(to))
+	return o
+}
+
+// parseUTCOffset parses "+0530", "-0300" or "+053000" into seconds.
+func parseUTCOffset(s string) (int, error) {
+	if (len(s) != 5 && len(s) != 7) || (s[0] != '+' && s[0] != '-') {
+		return 0, Errf("invalid UTC offset: %s", s)
+	}
+	h, ok1 := parseDigits(s[1:3])
+	m, ok2 := parseDigits(s[3:5])
+	sec, ok3 := 0, true
+	if len(s) == 7 {
+		sec, ok3 = parseDigits(s[5:7])
+	}
+	if !ok1 || !ok2 || !ok3 || m > 59 || sec > 59 {
+		return 0, Errf("invalid UTC offset: %s", s)
+	}
+	off := h*secondsPerHour + m*secondsPerMinute + sec
+	if s[0] == '-' {
+		off = -off
+	}
+	return off, nil
+}
+
+// formatUTCOffset formats seconds as "+HHMM" (with seconds when not zero).
+func formatUTCOffset(off int) string {
+	sign := byte('+')
+	if off < 0 {
+		sign, off = '-', -off
+	}
+	s := Sprintf("%c%02d%02d", sign, off/secondsPerHour, off%secondsPerHour/secondsPerMinute)
+	if sec := off % secondsPerMinute; sec != 0 {
+		s += Sprintf("%02d", sec)
+	}
+	return s
+}
@@ -0,0 +1,274 @@
+package time
+
+import (
+	"slices"
+)
+
+// Interval is a half-open UnixNano range [Start, End), e.g. a reservation's
+// reservation_at / reserved_until. It is empty when End <= Start.
+type Interval struct {
+	Start int64
+	End   int64
+}
+
+// IsEmpty reports whether the interval contains no instant.
+func (i Interval) IsEmpty() bool {
+	return i.End <= i.Start
+}
+
+// Duration returns End - Start in nanoseconds (0 when empty).
+func (i Interval) Duration() int64 {
+	if i.IsEmpty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Contains reports whether nano is in [Start, End).
+func (i Interval) Contains(nano int64) bool {
+	return i.Start <= nano && nano < i.End
+}
+
+// ContainsInterval reports whether o lies entirely within i. An empty o is contained.
+func (i Interval) ContainsInterval(o Interval) bool {
+	return o.IsEmpty() || (i.Start <= o.Start && o.End <= i.End)
+}
+
+// Overlaps reports whether i and o share at least one instant; touching intervals do not overlap.
+func (i Interval) Overlaps(o Interval) bool {
+	return i.Start < o.End && o.Start < i.End && !i.IsEmpty() && !o.IsEmpty()
+}
+
+// Intersect returns the common part of i and o, or the zero Interval if there is none.
+func (i Interval) Intersect(o Interval) Interval {
+	r := Interval{Start: max(i.Start, o.Start), End: min(i.End, o.End)}
+	if r.IsEmpty() {
+		return Interval{}
+	}
+	return r
+}
+
+// Clip is Intersect with a window.
+func (i Interval) Clip(window Interval) Interval {
+	return i.Intersect(window)
+}
+
+// Compare orders intervals by Start, then End.
+func (i Interval) Compare(o Interval) int {
+	switch {
+	case i.Start < o.Start:
+		return -1
+	case i.Start > o.Start:
+		return 1
+	case i.End < o.End:
+		return -1
+	case i.End > o.End:
+		return 1
+	}
+	return 0
+}
+
+// SplitDays cuts i at each local midnight of loc (DST-aware, via StartOf).
+func (i Interval) SplitDays(loc *Location) []Interval {
+	if i.IsEmpty() {
+		return nil
+	}
+	var out []Interval
+	for start := i.Start; start < i.End; {
+		end := min(EndOf(start, UnitDay, loc)+1, i.End)
+		out = append(out, Interval{Start: start, End: end})
+		start = end
+	}
+	return out
+}
+
+// IntervalSet is an immutable, sorted set of disjoint intervals. Overlapping
+// and touching intervals are merged, so the set is always in canonical form.
+// Operations are linear merges that allocate one result slice; the zero
+// value is the empty set.
+type IntervalSet struct {
+	items []Interval
+}
+
+// NewIntervalSet builds a set from intervals in any order in O(n log n).
+// Empty intervals are dropped; the argument is not modified.
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	items := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		if !i.IsEmpty() {
+			items = append(items, i)
+		}
+	}
+	slices.SortFunc(items, Interval.Compare)
+	out := items[:0]
+	for _, i := range items {
+		if n := len(out); n > 0 && i.Start <= out[n-1].End {
+			out[n-1].End = max(out[n-1].End, i.End)
+			continue
+		}
+		out = append(out, i)
+	}
+	return IntervalSet{items: out}
+}
+
+// Intervals returns the disjoint intervals in order. The slice is shared
+// with the set and must not be modified.
+func (s IntervalSet) Intervals() []Interval {
+	return s.items
+}
+
+// Len returns the number of disjoint intervals.
+func (s IntervalSet) Len() int {
+	return len(s.items)
+}
+
+// IsEmpty reports whether the set has no intervals.
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Duration returns the total covered nanoseconds.
+func (s IntervalSet) Duration() int64 {
+	var total int64
+	for _, i := range s.items {
+		total += i.End - i.Start
+	}
+	return total
+}
+
+// Bounds returns [first Start, last End), or the zero Interval for an empty set.
+func (s IntervalSet) Bounds() Interval {
+	if len(s.items) == 0 {
+		return Interval{}
+	}
+	return Interval{Start: s.items[0].Start, End: s.items[len(s.items)-1].End}
+}
+
+// find returns the index of the first interval whose End is after nano.
+func (s IntervalSet) find(nano int64) int {
+	lo, hi := 0, len(s.items)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if s.items[mid].End <= nano {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Contains reports whether nano is covered, in O(log n).
+func (s IntervalSet) Contains(nano int64) bool {
+	k := s.find(nano)
+	return k < len(s.items) && s.items[k].Start <= nano
+}
+
+// ContainsInterval reports whether i is entirely covered, in O(log n).
+func (s IntervalSet) ContainsInterval(i Interval) bool {
+	if i.IsEmpty() {
+		return true
+	}
+	k := s.find(i.Start)
+	return k < len(s.items) && s.items[k].ContainsInterval(i)
+}
+
+// Overlaps reports whether any interval of s overlaps i, in O(log n).
+func (s IntervalSet) Overlaps(i Interval) bool {
+	if i.IsEmpty() {
+		return false
+	}
+	k := s.find(i.Start)
+	return k < len(s.items) && s.items[k].Start < i.End
+}
+
+// Add returns s with i merged in.
+func (s IntervalSet) Add(i Interval) IntervalSet {
+	if i.IsEmpty() {
+		return s
+	}
+	return s.Union(IntervalSet{items: []Interval{i}})
+}
+
+// Union returns the instants covered by s or o.
+func (s IntervalSet) Union(o IntervalSet) IntervalSet {
+	out := make([]Interval, 0, len(s.items)+len(o.items))
+	a, b := s.items, o.items
+	for len(a) > 0 || len(b) > 0 {
+		var next Interval
+		if len(b) == 0 || (len(a) > 0 && a[0].Start <= b[0].Start) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+		if n := len(out); n > 0 && next.Start <= out[n-1].End {
+			out[n-1].End = max(out[n-1].End, next.End)
+			continue
+		}
+		out = append(out, next)
+	}
+	return IntervalSet{items: out}
+}
+
+// Intersect returns the instants covered by both s and o.
+func (s IntervalSet) Intersect(o IntervalSet) IntervalSet {
+	var out []Interval
+	a, b := s.items, o.items
+	for len(a) > 0 && len(b) > 0 {
+		if r := a[0].Intersect(b[0]); !r.IsEmpty() {
+			out = append(out, r)
+		}
+		if a[0].End < b[0].End {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return IntervalSet{items: out}
+}
+
+// Subtract returns the instants covered by s but not by o.
+func (s IntervalSet) Subtract(o IntervalSet) IntervalSet {
+	out := make([]Interval, 0, len(s.items))
+	b := o.items
+	for _, cur := range s.items {
+		for len(b) > 0 && b[0].End <= cur.Start {
+			b = b[1:]
+		}
+		for k := 0; k < len(b) && b[k].Start < cur.End; k++ {
+			if b[k].Start > cur.Start {
+				out = append(out, Interval{Start: cur.Start, End: b[k].Start})
+			}
+			cur.Start = max(cur.Start, b[k].End)
+		}
+		if !cur.IsEmpty() {
+			out = append(out, cur)
+		}
+	}
+	return IntervalSet{items: out}
+}
+
+// Clip returns the part of s inside window.
+func (s IntervalSet) Clip(window Interval) IntervalSet {
+	if window.IsEmpty() {
+		return IntervalSet{}
+	}
+	return s.Intersect(IntervalSet{items: []Interval{window}})
+}
+
+// Gaps returns the uncovered parts of window, e.g. free time between reservations.
+func (s IntervalSet) Gaps(window Interval) IntervalSet {
+	if window.IsEmpty() {
+		return IntervalSet{}
+	}
+	return IntervalSet{items: []Interval{window}}.Subtract(s)
+}
+
+// SplitDays cuts every interval of s at local midnights of loc.
+func (s IntervalSet) SplitDays(loc *Location) []Interval {
+	var out []Interval
+	for _, i := range s.items {
+		out = append(out, i.SplitDays(loc)...)
+	}
+	return out
+}
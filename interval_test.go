@@ -0,0 +1,89 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test interval algebra
+func IntervalShared(t *testing.T) {
+	iv := func(a, b int64) time.Interval { return time.Interval{Start: a, End: b} }
+	same := func(name string, got []time.Interval, want ...time.Interval) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s = %v; want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v; want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	a := iv(10, 20)
+	if !a.Contains(10) || a.Contains(20) || a.Duration() != 10 {
+		t.Error("Interval should be half-open [Start, End)")
+	}
+	if a.Overlaps(iv(20, 30)) || !a.Overlaps(iv(19, 30)) || a.Overlaps(iv(15, 15)) {
+		t.Error("Overlaps: touching or empty intervals must not overlap")
+	}
+	if got := a.Intersect(iv(15, 40)); got != iv(15, 20) {
+		t.Errorf("Intersect = %v", got)
+	}
+	if got := a.Clip(iv(30, 40)); !got.IsEmpty() {
+		t.Errorf("Clip outside window = %v; want empty", got)
+	}
+	if !a.ContainsInterval(iv(12, 20)) || a.ContainsInterval(iv(12, 21)) {
+		t.Error("ContainsInterval mismatch")
+	}
+
+	s := time.NewIntervalSet(iv(50, 60), iv(10, 20), iv(15, 30), iv(30, 35), iv(40, 40))
+	same("NewIntervalSet", s.Intervals(), iv(10, 35), iv(50, 60))
+	if s.Duration() != 35 || s.Bounds() != iv(10, 60) {
+		t.Errorf("Duration/Bounds = %d %v", s.Duration(), s.Bounds())
+	}
+	if !s.Contains(34) || s.Contains(35) || s.Contains(45) || !s.Contains(50) {
+		t.Error("IntervalSet.Contains mismatch")
+	}
+	if !s.Overlaps(iv(30, 51)) || s.Overlaps(iv(35, 50)) || !s.ContainsInterval(iv(11, 35)) || s.ContainsInterval(iv(30, 55)) {
+		t.Error("IntervalSet.Overlaps/ContainsInterval mismatch")
+	}
+
+	o := time.NewIntervalSet(iv(0, 12), iv(33, 52), iv(58, 70))
+	same("Union", s.Union(o).Intervals(), iv(0, 70))
+	same("Intersect", s.Intersect(o).Intervals(), iv(10, 12), iv(33, 35), iv(50, 52), iv(58, 60))
+	same("Subtract", s.Subtract(o).Intervals(), iv(12, 33), iv(52, 58))
+	same("Subtract(split)", s.Subtract(time.NewIntervalSet(iv(12, 14), iv(20, 22))).Intervals(),
+		iv(10, 12), iv(14, 20), iv(22, 35), iv(50, 60))
+	same("Gaps", s.Gaps(iv(0, 100)).Intervals(), iv(0, 10), iv(35, 50), iv(60, 100))
+	same("Clip", s.Clip(iv(20, 55)).Intervals(), iv(20, 35), iv(50, 55))
+	same("Add", s.Add(iv(35, 50)).Intervals(), iv(10, 60))
+	if !(time.IntervalSet{}).Union(time.IntervalSet{}).IsEmpty() {
+		t.Error("union of empty sets should be empty")
+	}
+
+	// Many unsorted intervals collapse to one
+	many := make([]time.Interval, 0, 5000)
+	for i := int64(4999); i >= 0; i-- {
+		many = append(many, iv(i*10, i*10+10))
+	}
+	same("NewIntervalSet(many)", time.NewIntervalSet(many...).Intervals(), iv(0, 50000))
+
+	// Split at local midnights, including Santiago's 23-hour day
+	const h = int64(3600 * 1000000000)
+	utc := time.Date{Year: 2024, Month: 5, Day: 1}.UnixNanoUTC()
+	same("SplitDays(UTC)", iv(utc+20*h, utc+50*h).SplitDays(time.UTC),
+		iv(utc+20*h, utc+24*h), iv(utc+24*h, utc+48*h), iv(utc+48*h, utc+50*h))
+	scl, _ := time.LoadLocation("America/Santiago")
+	sep7 := int64(1725681600) * 1000000000 // 2024-09-07 00:00 -04
+	parts := iv(sep7, sep7+47*h).SplitDays(scl)
+	if len(parts) != 2 || parts[0].Duration() != 24*h || parts[1].Duration() != 23*h {
+		t.Errorf("SplitDays(Santiago) = %v", parts)
+	}
+	if got := s.SplitDays(time.UTC); len(got) != 2 {
+		t.Errorf("IntervalSet.SplitDays = %v", got)
+	}
+}
@@ -0,0 +1,88 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// ISOWeek returns the ISO 8601 week-numbering year and week (1-53) of d.
+// Weeks start on Monday and week 1 is the one containing the year's first
+// Thursday, so early January may belong to the previous week-year.
+func (d Date) ISOWeek() (year, week int) {
+	thursday := d.AddDays(4 - d.Weekday().ISO())
+	return thursday.Year, (thursday.YearDay()-1)/7 + 1
+}
+
+// ISOWeek returns the ISO 8601 week-year and week of a UnixNano timestamp in the active timezone offset.
+func ISOWeek(nano int64) (year, week int) {
+	return DateOf(nano).ISOWeek()
+}
+
+// ISOWeek returns the ISO 8601 week-year and week of t in its location.
+func (t Time) ISOWeek() (year, week int) {
+	return t.DateTime().Date.ISOWeek()
+}
+
+// ISOWeeksInYear returns the number of ISO weeks (52 or 53) in a week-year.
+func ISOWeeksInYear(year int) int {
+	_, week := Date{Year: year, Month: December, Day: 28}.ISOWeek()
+	return week
+}
+
+// DateFromISOWeek returns the date of day in the given ISO week-year and week.
+func DateFromISOWeek(year, week int, day DayOfWeek) (Date, error) {
+	if week < 1 || week > ISOWeeksInYear(year) || !day.IsValid() {
+		return Date{}, Errf("invalid ISO week date: %d-W%02d-%d", year, week, day.ISO())
+	}
+	jan4 := Date{Year: year, Month: January, Day: 4}
+	monday := jan4.AddDays(1 - jan4.Weekday().ISO())
+	return monday.AddDays((week-1)*7 + day.ISO() - 1), nil
+}
+
+// FormatISOWeek formats the ISO week of a UnixNano timestamp in the active timezone as "2024-W03".
+func FormatISOWeek(nano int64) string {
+	year, week := ISOWeek(nano)
+	return formatYear(year) + Sprintf("-W%02d", week)
+}
+
+// ISOWeekString formats d as an ISO 8601 week date, e.g. "2024-W03-2".
+func (d Date) ISOWeekString() string {
+	year, week := d.ISOWeek()
+	return formatYear(year) + Sprintf("-W%02d-%d", week, d.Weekday().ISO())
+}
+
+// ParseISOWeek parses an ISO 8601 week date: "2024-W03", "2024-W03-2" or the
+// compact "2024W03" / "2024W032". Without a day it returns the Monday.
+func ParseISOWeek(s string) (Date, error) {
+	rest := s
+	if len(rest) < 7 {
+		return Date{}, Errf("invalid ISO week format: %s", s)
+	}
+	year, ok := parseDigits(rest[:4])
+	rest = rest[4:]
+	extended := rest[0] == '-'
+	if extended {
+		rest = rest[1:]
+	}
+	if !ok || len(rest) < 3 || rest[0] != 'W' {
+		return Date{}, Errf("invalid ISO week format: %s", s)
+	}
+	week, ok := parseDigits(rest[1:3])
+	rest = rest[3:]
+	day := 1
+	if ok && rest != "" {
+		if extended {
+			if rest[0] != '-' {
+				return Date{}, Errf("invalid ISO week format: %s", s)
+			}
+			rest = rest[1:]
+		}
+		if len(rest) != 1 {
+			return Date{}, Errf("invalid ISO week format: %s", s)
+		}
+		day, ok = parseDigits(rest)
+	}
+	if !ok || day < 1 || day > 7 {
+		return Date{}, Errf("invalid ISO week format: %s", s)
+	}
+	return DateFromISOWeek(year, week, DayOfWeekFromISO(day))
+}
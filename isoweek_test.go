@@ -0,0 +1,65 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test ISO 8601 week numbering
+func ISOWeekShared(t *testing.T) {
+	cases := []struct {
+		date string
+		want string
+	}{
+		{"2024-01-17", "2024-W03-3"},
+		{"2021-01-03", "2020-W53-7"}, // Sunday before week 1 belongs to 2020
+		{"2019-12-30", "2020-W01-1"}, // Monday of week 1 is still in December
+		{"2026-12-31", "2026-W53-4"},
+		{"2027-01-01", "2026-W53-5"},
+		{"2008-12-29", "2009-W01-1"},
+	}
+	for _, c := range cases {
+		d, err := time.ParseCivilDate(c.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.ISOWeekString(); got != c.want {
+			t.Errorf("ISOWeekString(%s) = %s; want %s", c.date, got, c.want)
+		}
+		back, err := time.ParseISOWeek(c.want)
+		if err != nil || back != d {
+			t.Errorf("ParseISOWeek(%s) = %s, %v; want %s", c.want, back, err, c.date)
+		}
+	}
+
+	for year, want := range map[int]int{2015: 53, 2020: 53, 2021: 52, 2024: 52, 2026: 53} {
+		if got := time.ISOWeeksInYear(year); got != want {
+			t.Errorf("ISOWeeksInYear(%d) = %d; want %d", year, got, want)
+		}
+	}
+
+	if d, err := time.ParseISOWeek("2024W03"); err != nil || d.String() != "2024-01-15" {
+		t.Errorf("ParseISOWeek(2024W03) = %s, %v", d, err)
+	}
+	if d, err := time.DateFromISOWeek(2024, 3, time.Sunday); err != nil || d.String() != "2024-01-21" {
+		t.Errorf("DateFromISOWeek(2024, 3, Sunday) = %s, %v", d, err)
+	}
+	for _, bad := range []string{"2024-W54", "2021-W53", "2024-W03-8", "2024-W3", "2024-W03x", "2024W03-1"} {
+		if _, err := time.ParseISOWeek(bad); err == nil {
+			t.Errorf("ParseISOWeek(%q) should return error", bad)
+		}
+	}
+
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+	time.SetTimeZoneOffset(-3)
+	// 2024-01-15 01:00 UTC is still Sunday of week 2 in UTC-3
+	nano := time.Date{Year: 2024, Month: 1, Day: 15}.UnixNanoUTC() + 3600*1000000000
+	if got := time.FormatISOWeek(nano); got != "2024-W02" {
+		t.Errorf("FormatISOWeek = %s; want 2024-W02", got)
+	}
+	if y, w := time.TimeOf(nano).UTC().ISOWeek(); y != 2024 || w != 3 {
+		t.Errorf("Time.ISOWeek(UTC) = %d-W%d; want 2024-W3", y, w)
+	}
+}
@@ -0,0 +1,204 @@
+package time
+
+// Pure-Go subset of the standard library reference layout
+// ("Mon Jan 2 15:04:05 MST 2006"), so formatting is identical on every
+// provider. Supported elements:
+//
+//	Year:     2006 06          Weekday: Monday Mon
+//	Month:    January Jan 01 1 Day:     02 _2 2, day of year 002
+//	Hour:     15 03 3          AM/PM:   PM pm
+//	Minute:   04 4             Second:  05 5
+//	Fraction: .000 .999 (or ,) with 1 to 9 digits
+//	Zone:     MST Z07:00 Z0700 Z07 -07:00 -0700 -07
+//
+// Month and day names follow SetMonthNames and SetDayNames.
+
+// formatLayout renders dt (wall time at offsetSec seconds east of UTC) with layout.
+func formatLayout(layout string, dt DateTime, offsetSec int, zoneName string) string {
+	buf := make([]byte, 0, len(layout)+10)
+	for i := 0; i < len(layout); {
+		tok, n := layoutToken(layout[i:])
+		if n == 0 {
+			buf = append(buf, layout[i])
+			i++
+			continue
+		}
+		i += n
+		buf = appendElement(buf, tok, dt, offsetSec, zoneName)
+	}
+	return string(buf)
+}
+
+// layoutTokens lists the elements in matching priority (longest first).
+var layoutTokens = []string{
+	"January", "Jan", "Monday", "Mon", "MST",
+	"2006", "002", "01", "02", "03", "04", "05", "06",
+	"15", "1", "_2", "2", "3", "4", "5", "PM", "pm",
+	"Z07:00", "Z0700", "Z07", "-07:00", "-0700", "-07",
+}
+
+// layoutToken returns the element at the start of s and its length, or 0.
+func layoutToken(s string) (string, int) {
+	if s[0] == '.' || s[0] == ',' {
+		if n := fractionToken(s); n > 0 {
+			return s[:n], n
+		}
+		return "", 0
+	}
+	for _, tok := range layoutTokens {
+		if len(s) >= len(tok) && s[:len(tok)] == tok {
+			return tok, len(tok)
+		}
+	}
+	return "", 0
+}
+
+// fractionToken returns the length of ".000"/".999" at the start of s, or 0.
+func fractionToken(s string) int {
+	if len(s) < 2 || (s[1] != '0' && s[1] != '9') {
+		return 0
+	}
+	j := 1
+	for j < len(s) && s[j] == s[1] {
+		j++
+	}
+	if j-1 > 9 || (j < len(s) && s[j] >= '0' && s[j] <= '9') {
+		return 0
+	}
+	return j
+}
+
+func appendElement(buf []byte, tok string, dt DateTime, offsetSec int, zoneName string) []byte {
+	d, t := dt.Date, dt.Time
+	switch tok {
+	case "2006":
+		return appendYear(buf, d.Year)
+	case "06":
+		return appendInt(buf, int(floorMod(int64(d.Year), 100)), 2)
+	case "January":
+		return append(buf, d.Month.String()...)
+	case "Jan":
+		return append(buf, d.Month.Short()...)
+	case "01":
+		return appendInt(buf, int(d.Month), 2)
+	case "1":
+		return appendInt(buf, int(d.Month), 0)
+	case "Monday":
+		return append(buf, d.Weekday().String()...)
+	case "Mon":
+		return append(buf, d.Weekday().Short()...)
+	case "02":
+		return appendInt(buf, d.Day, 2)
+	case "_2":
+		if d.Day < 10 {
+			buf = append(buf, ' ')
+		}
+		return appendInt(buf, d.Day, 0)
+	case "2":
+		return appendInt(buf, d.Day, 0)
+	case "002":
+		return appendInt(buf, d.YearDay(), 3)
+	case "15":
+		return appendInt(buf, t.Hour, 2)
+	case "03":
+		return appendInt(buf, hour12(t.Hour), 2)
+	case "3":
+		return appendInt(buf, hour12(t.Hour), 0)
+	case "04":
+		return appendInt(buf, t.Minute, 2)
+	case "4":
+		return appendInt(buf, t.Minute, 0)
+	case "05":
+		return appendInt(buf, t.Second, 2)
+	case "5":
+		return appendInt(buf, t.Second, 0)
+	case "PM", "pm":
+		s := "AM"
+		if t.Hour >= 12 {
+			s = "PM"
+		}
+		if tok == "pm" {
+			s = string([]byte{s[0] + 'a' - 'A', 'm'})
+		}
+		return append(buf, s...)
+	case "MST":
+		if zoneName != "" {
+			return append(buf, zoneName...)
+		}
+		return appendOffset(buf, offsetSec, false, true)
+	case "Z07:00", "Z0700", "Z07":
+		if offsetSec == 0 {
+			return append(buf, 'Z')
+		}
+		return appendOffset(buf, offsetSec, tok == "Z07:00", tok != "Z07")
+	case "-07:00", "-0700", "-07":
+		return appendOffset(buf, offsetSec, tok == "-07:00", tok != "-07")
+	}
+	// fraction: .000 (fixed digits) or .999 (trailing zeros trimmed)
+	digits := len(tok) - 1
+	frac := []byte(formatFraction(t.Nanosecond) + "000000000")[1 : digits+1]
+	if tok[1] == '9' {
+		for len(frac) > 0 && frac[len(frac)-1] == '0' {
+			frac = frac[:len(frac)-1]
+		}
+		if len(frac) == 0 {
+			return buf
+		}
+	}
+	buf = append(buf, tok[0])
+	return append(buf, frac...)
+}
+
+func hour12(h int) int {
+	h %= 12
+	if h == 0 {
+		return 12
+	}
+	return h
+}
+
+// appendInt appends n zero-padded to width digits (width 0 means no padding).
+func appendInt(buf []byte, n, width int) []byte {
+	if n < 0 {
+		buf = append(buf, '-')
+		n = -n
+	}
+	var tmp [20]byte
+	i := len(tmp)
+	for {
+		i--
+		tmp[i] = byte('0' + n%10)
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	for len(tmp)-i < width {
+		i--
+		tmp[i] = '0'
+	}
+	return append(buf, tmp[i:]...)
+}
+
+// appendYear appends a four digit year (negative years get a leading '-').
+func appendYear(buf []byte, year int) []byte {
+	return appendInt(buf, year, 4)
+}
+
+// appendOffset appends ±hh[:mm] or ±hhmm.
+func appendOffset(buf []byte, offsetSec int, colon, minutes bool) []byte {
+	sign := byte('+')
+	if offsetSec < 0 {
+		sign = '-'
+		offsetSec = -offsetSec
+	}
+	buf = append(buf, sign)
+	buf = appendInt(buf, offsetSec/secondsPerHour, 2)
+	if !minutes {
+		return buf
+	}
+	if colon {
+		buf = append(buf, ':')
+	}
+	return appendInt(buf, offsetSec%secondsPerHour/secondsPerMinute, 2)
+}
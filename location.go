@@ -0,0 +1,146 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+type locationKind uint8
+
+const (
+	locationActive locationKind = iota // follows SetTimeZoneOffset
+	locationFixed
+	locationZone  // IANA name resolved by the provider
+	locationRules // transitions defined by the package, e.g. an iCalendar VTIMEZONE
+)
+
+// Location maps UTC instants to local wall-clock time.
+// A nil *Location behaves like Local.
+type Location struct {
+	name   string
+	kind   locationKind
+	offset int        // seconds east of UTC, locationFixed only
+	rules  *zoneRules // locationRules only
+}
+
+var (
+	// UTC is Coordinated Universal Time.
+	UTC = &Location{name: "UTC", kind: locationFixed}
+
+	// Local follows the active timezone offset (see SetTimeZoneOffset).
+	Local = &Location{name: "Local", kind: locationActive}
+)
+
+// FixedZone returns a Location that always uses the given offset in seconds east of UTC.
+func FixedZone(name string, offsetSeconds int) *Location {
+	return &Location{name: name, kind: locationFixed, offset: offsetSeconds}
+}
+
+// LoadLocation returns the Location for an IANA timezone name (e.g. "America/Santiago").
+// "" and "UTC" return UTC, "Local" returns Local.
+// Backend: resolved with the standard library tz database.
+// WASM: resolved with the JavaScript Intl API.
+func LoadLocation(name string) (*Location, error) {
+	switch name {
+	case "", "UTC":
+		return UTC, nil
+	case "Local":
+		return Local, nil
+	}
+	if _, ok := provider().ZoneOffset(name, 0); !ok {
+		return nil, Errf("unknown time zone: %s", name)
+	}
+	return &Location{name: name, kind: locationZone}, nil
+}
+
+// String returns the name of the location.
+func (l *Location) String() string {
+	if l == nil {
+		return Local.name
+	}
+	return l.name
+}
+
+// Offset returns the offset in seconds east of UTC in effect at the UnixNano instant.
+func (l *Location) Offset(nano int64) int {
+	sec, _ := splitNano(nano)
+	return int(l.offsetAt(sec))
+}
+
+func (l *Location) offsetAt(unixSec int64) int64 {
+	if l == nil {
+		return localOffsetSeconds()
+	}
+	switch l.kind {
+	case locationFixed:
+		return int64(l.offset)
+	case locationZone:
+		if off, ok := provider().ZoneOffset(l.name, unixSec); ok {
+			return int64(off)
+		}
+		return 0
+	case locationRules:
+		return int64(l.rules.offsetAt(unixSec))
+	}
+	return localOffsetSeconds()
+}
+
+// Disambiguation selects how a local wall-clock time that does not map to
+// exactly one instant is resolved.
+type Disambiguation int
+
+const (
+	// Compatible picks the earlier instant in an overlap and moves forward by
+	// the length of the gap for nonexistent times (same as stdlib and JS).
+	Compatible Disambiguation = iota
+	// Earlier picks the earlier of the two candidate instants.
+	Earlier
+	// Later picks the later of the two candidate instants.
+	Later
+	// Reject returns an error for nonexistent or ambiguous times.
+	Reject
+)
+
+// resolve converts local wall-clock seconds into Unix seconds.
+func (l *Location) resolve(local int64, policy Disambiguation) (int64, error) {
+	if l != nil && (l.kind == locationZone || l.kind == locationRules) {
+		return l.resolveZone(local, policy)
+	}
+	return local - l.offsetAt(local), nil
+}
+
+// resolveZone handles offset transitions by testing the offsets in effect one
+// day before and after the wall time. Zones never change twice within a day.
+func (l *Location) resolveZone(local int64, policy Disambiguation) (int64, error) {
+	before := l.offsetAt(local - secondsPerDay)
+	after := l.offsetAt(local + secondsPerDay)
+	t1, t2 := local-before, local-after
+	ok1 := l.offsetAt(t1) == before
+	ok2 := l.offsetAt(t2) == after
+
+	switch {
+	case ok1 && ok2 && t1 != t2: // overlap: the wall time happens twice
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		switch policy {
+		case Later:
+			return t2, nil
+		case Reject:
+			return 0, Errf("ambiguous local time in %s", l.name)
+		}
+		return t1, nil
+	case ok1:
+		return t1, nil
+	case ok2:
+		return t2, nil
+	}
+
+	// gap: the wall time is skipped
+	switch policy {
+	case Earlier:
+		return local - after, nil
+	case Reject:
+		return 0, Errf("nonexistent local time in %s", l.name)
+	}
+	return local - before, nil
+}
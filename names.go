@@ -0,0 +1,259 @@
+package time
+
+import (
+	"sync/atomic"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Month is a month of the year (January = 1 … December = 12).
+type Month int
+
+const (
+	January Month = 1 + iota
+	February
+	March
+	April
+	May
+	June
+	July
+	August
+	September
+	October
+	November
+	December
+)
+
+// DayOfWeek is a day of the week using the package's Sunday=0 convention
+// (the same numbering returned by Weekday). Use ISO for Monday=1 numbering.
+type DayOfWeek int
+
+const (
+	Sunday DayOfWeek = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// calendarNames holds the display names used by String and Short.
+type calendarNames struct {
+	months      [12]string
+	monthsShort [12]string
+	days        [7]string
+	daysShort   [7]string
+}
+
+var englishNames = calendarNames{
+	months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	monthsShort: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	daysShort:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+}
+
+var names atomic.Pointer[calendarNames]
+
+// weekendDays stores the active weekend as a DaySet.
+var weekendDays atomic.Uint32
+
+// firstDay stores the first day of the week (Monday by default, as in ISO 8601).
+var firstDay atomic.Int32
+
+func init() {
+	names.Store(&englishNames)
+	weekendDays.Store(uint32(NewDaySet(Saturday, Sunday)))
+	firstDay.Store(int32(Monday))
+}
+
+// SetMonthNames replaces the full and abbreviated month names used by
+// Month.String and Month.Short (January first). English is the default.
+func SetMonthNames(full, short [12]string) {
+	n := *names.Load()
+	n.months, n.monthsShort = full, short
+	names.Store(&n)
+}
+
+// SetDayNames replaces the full and abbreviated day names used by
+// DayOfWeek.String and DayOfWeek.Short (Sunday first). English is the default.
+func SetDayNames(full, short [7]string) {
+	n := *names.Load()
+	n.days, n.daysShort = full, short
+	names.Store(&n)
+}
+
+// IsValid reports whether m is between January and December.
+func (m Month) IsValid() bool {
+	return m >= January && m <= December
+}
+
+// String returns the full (localized) name of the month.
+func (m Month) String() string {
+	if !m.IsValid() {
+		return Sprintf("Month(%d)", int(m))
+	}
+	return names.Load().months[m-1]
+}
+
+// Short returns the abbreviated (localized) name of the month.
+func (m Month) Short() string {
+	if !m.IsValid() {
+		return m.String()
+	}
+	return names.Load().monthsShort[m-1]
+}
+
+// Add returns the month n months after m, wrapping around December.
+func (m Month) Add(n int) Month {
+	return Month(floorMod(int64(m)-1+int64(n), 12) + 1)
+}
+
+// Months returns January through December in order.
+func Months() []Month {
+	out := make([]Month, 12)
+	for i := range out {
+		out[i] = January + Month(i)
+	}
+	return out
+}
+
+// ParseMonth parses a month name (English or the names set with
+// SetMonthNames, full or abbreviated, case-insensitive) or a number 1-12.
+func ParseMonth(s string) (Month, error) {
+	if n, ok := parseDigits(s); ok && n >= 1 && n <= 12 {
+		return Month(n), nil
+	}
+	if i := matchName(s, englishNames.months[:], englishNames.monthsShort[:], names.Load().months[:], names.Load().monthsShort[:]); i >= 0 {
+		return Month(i + 1), nil
+	}
+	return 0, Errf("invalid month: %s", s)
+}
+
+// IsValid reports whether d is between Sunday and Saturday.
+func (d DayOfWeek) IsValid() bool {
+	return d >= Sunday && d <= Saturday
+}
+
+// String returns the full (localized) name of the day.
+func (d DayOfWeek) String() string {
+	if !d.IsValid() {
+		return Sprintf("DayOfWeek(%d)", int(d))
+	}
+	return names.Load().days[d]
+}
+
+// Short returns the abbreviated (localized) name of the day.
+func (d DayOfWeek) Short() string {
+	if !d.IsValid() {
+		return d.String()
+	}
+	return names.Load().daysShort[d]
+}
+
+// ISO returns the ISO 8601 day number: Monday=1 … Sunday=7.
+func (d DayOfWeek) ISO() int {
+	if d == Sunday {
+		return 7
+	}
+	return int(d)
+}
+
+// DayOfWeekFromISO converts an ISO 8601 day number (Monday=1 … Sunday=7) into a DayOfWeek.
+func DayOfWeekFromISO(n int) DayOfWeek {
+	return DayOfWeek(floorMod(int64(n), 7))
+}
+
+// Add returns the day n days after d, wrapping around the week.
+func (d DayOfWeek) Add(n int) DayOfWeek {
+	return DayOfWeek(floorMod(int64(d)+int64(n), 7))
+}
+
+// IsWeekend reports whether d belongs to the active weekend (see SetWeekend).
+func (d DayOfWeek) IsWeekend() bool {
+	return Weekend().Contains(d)
+}
+
+// Week returns the seven days of the week starting at first.
+func Week(first DayOfWeek) [7]DayOfWeek {
+	var out [7]DayOfWeek
+	for i := range out {
+		out[i] = first.Add(i)
+	}
+	return out
+}
+
+// ParseDayOfWeek parses a day name (English or the names set with
+// SetDayNames, full or abbreviated, case-insensitive).
+func ParseDayOfWeek(s string) (DayOfWeek, error) {
+	if i := matchName(s, englishNames.days[:], englishNames.daysShort[:], names.Load().days[:], names.Load().daysShort[:]); i >= 0 {
+		return DayOfWeek(i), nil
+	}
+	return 0, Errf("invalid day of week: %s", s)
+}
+
+// matchName returns the index of s in any of the lists, ignoring case, or -1.
+func matchName(s string, lists ...[]string) int {
+	s = ToLower(TrimSpace(s))
+	if s == "" {
+		return -1
+	}
+	for _, list := range lists {
+		for i, name := range list {
+			if ToLower(name) == s {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// DaySet is a set of days of the week, used for weekend definitions.
+type DaySet uint8
+
+// NewDaySet returns a DaySet containing days.
+func NewDaySet(days ...DayOfWeek) DaySet {
+	var s DaySet
+	for _, d := range days {
+		s |= 1 << uint(d)
+	}
+	return s
+}
+
+// Contains reports whether d is in the set.
+func (s DaySet) Contains(d DayOfWeek) bool {
+	return d.IsValid() && s&(1<<uint(d)) != 0
+}
+
+// Days returns the days in the set, Sunday first.
+func (s DaySet) Days() []DayOfWeek {
+	var out []DayOfWeek
+	for d := Sunday; d <= Saturday; d++ {
+		if s.Contains(d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// SetWeekend sets the days treated as weekend by DayOfWeek.IsWeekend
+// (Saturday and Sunday by default; e.g. Friday and Saturday in many Middle East locales).
+func SetWeekend(days ...DayOfWeek) {
+	weekendDays.Store(uint32(NewDaySet(days...)))
+}
+
+// Weekend returns the active weekend days.
+func Weekend() DaySet {
+	return DaySet(weekendDays.Load())
+}
+
+// SetFirstDayOfWeek sets the day weeks start on for StartOf/EndOf with
+// UnitWeek (Monday by default; Sunday in the US locale).
+func SetFirstDayOfWeek(d DayOfWeek) {
+	firstDay.Store(int32(d))
+}
+
+// FirstDayOfWeek returns the day weeks start on.
+func FirstDayOfWeek() DayOfWeek {
+	return DayOfWeek(firstDay.Load())
+}
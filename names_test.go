@@ -0,0 +1,69 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test Month and DayOfWeek
+func NamesShared(t *testing.T) {
+	if time.March.String() != "March" || time.March.Short() != "Mar" {
+		t.Errorf("March = %s/%s", time.March.String(), time.March.Short())
+	}
+	if time.December.Add(1) != time.January || time.January.Add(-1) != time.December {
+		t.Error("Month.Add does not wrap")
+	}
+	if len(time.Months()) != 12 || time.Months()[11] != time.December {
+		t.Error("Months() should list January through December")
+	}
+	for in, want := range map[string]time.Month{"september": time.September, "SEP": time.September, "9": time.September} {
+		if m, err := time.ParseMonth(in); err != nil || m != want {
+			t.Errorf("ParseMonth(%q) = %v, %v; want %v", in, m, err, want)
+		}
+	}
+	if _, err := time.ParseMonth("13"); err == nil {
+		t.Error("ParseMonth(13) should return error")
+	}
+
+	// Sunday=0 and ISO numbering
+	if time.Sunday.ISO() != 7 || time.Monday.ISO() != 1 || time.DayOfWeekFromISO(7) != time.Sunday {
+		t.Error("ISO numbering failed")
+	}
+	if time.DayOfWeek(time.Weekday(1609459200)) != time.Friday {
+		t.Error("DayOfWeek should match the Weekday convention")
+	}
+	if time.Saturday.Add(1) != time.Sunday || time.Week(time.Monday)[6] != time.Sunday {
+		t.Error("DayOfWeek iteration failed")
+	}
+	if d, err := time.ParseDayOfWeek("wed"); err != nil || d != time.Wednesday {
+		t.Errorf("ParseDayOfWeek(wed) = %v, %v", d, err)
+	}
+
+	// Localized names
+	time.SetDayNames(
+		[7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		[7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	)
+	if time.Wednesday.String() != "miércoles" {
+		t.Errorf("localized Wednesday = %s", time.Wednesday)
+	}
+	if d, err := time.ParseDayOfWeek("Miércoles"); err != nil || d != time.Wednesday {
+		t.Errorf("ParseDayOfWeek(Miércoles) = %v, %v", d, err)
+	}
+	time.SetDayNames(
+		[7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		[7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	)
+
+	// Weekend definition
+	if !time.Saturday.IsWeekend() || time.Friday.IsWeekend() {
+		t.Error("default weekend should be Saturday and Sunday")
+	}
+	initial := time.Weekend()
+	time.SetWeekend(time.Friday, time.Saturday)
+	if !time.Friday.IsWeekend() || time.Sunday.IsWeekend() {
+		t.Error("SetWeekend(Friday, Saturday) not applied")
+	}
+	time.SetWeekend(initial.Days()...)
+}
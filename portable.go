@@ -0,0 +1,144 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// PortableProvider implements the formatting and parsing half of Provider
+// in pure Go with integer civil arithmetic, exact to the nanosecond and
+// identical to the backend's output. The browser provider embeds
+// it, and so can custom providers, which then supply UnixNano, Monotonic,
+// ZoneOffset, AfterFunc and TickFunc.
+type PortableProvider struct{}
+
+// localDateTime returns the wall clock of nano in the active timezone offset.
+func localDateTime(nano int64) DateTime {
+	sec, nsec := splitNano(nano)
+	return dateTimeFromLocal(sec+localOffsetSeconds(), nsec)
+}
+
+func formatHMS(t TimeOfDay) string {
+	return Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// isLayout reports whether s is "YYYY-MM-DD", "YYYY-MM-DD HH:MM" or
+// "YYYY-MM-DD HH:MM:SS" (by length) with valid fields.
+func isLayout(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	if _, err := ParseCivilDate(s[:10]); err != nil {
+		return false
+	}
+	if length == 10 {
+		return true
+	}
+	_, err := ParseTimeOfDay(s[11:])
+	return s[10] == ' ' && err == nil
+}
+
+func (PortableProvider) FormatDate(value any) string {
+	switch v := value.(type) {
+	case int64:
+		return localDateTime(v).Date.String()
+	case string:
+		if isLayout(v, 10) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (PortableProvider) FormatTime(value any) string {
+	switch v := value.(type) {
+	case int64: // UnixNano
+		return formatHMS(localDateTime(v).Time)
+	case int16: // Minutes since midnight
+		return Sprintf("%02d:%02d", v/60, v%60)
+	case string:
+		if nano, err := Convert(v).Int64(); err == nil {
+			return formatHMS(localDateTime(nano).Time)
+		}
+		if Count(v, ":") >= 1 {
+			return v
+		}
+	}
+	return ""
+}
+
+func (PortableProvider) FormatDateTime(value any) string {
+	switch v := value.(type) {
+	case int64:
+		dt := localDateTime(v)
+		return dt.Date.String() + " " + formatHMS(dt.Time)
+	case string:
+		if isLayout(v, 19) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (PortableProvider) FormatDateTimeShort(value any) string {
+	switch v := value.(type) {
+	case int64:
+		dt := localDateTime(v)
+		return dt.Date.String() + " " + formatHMS(dt.Time)[:5]
+	case string:
+		if isLayout(v, 16) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (PortableProvider) FormatISO8601(nano int64) string {
+	dt := DateTimeOf(nano, UTC)
+	return dt.Date.String() + "T" + formatHMS(dt.Time) + "Z"
+}
+
+func (PortableProvider) FormatCompact(nano int64) string {
+	dt := DateTimeOf(nano, UTC)
+	return Sprintf("%04d%02d%02d%02d%02d%02d", dt.Date.Year, int(dt.Date.Month), dt.Date.Day, dt.Time.Hour, dt.Time.Minute, dt.Time.Second)
+}
+
+func (PortableProvider) ParseDate(dateStr string) (int64, error) {
+	d, err := ParseCivilDate(dateStr)
+	if err != nil {
+		return 0, err
+	}
+	nano, ok := unixNanoOf(d.days()*secondsPerDay, 0)
+	if !ok {
+		return 0, Errf("date out of range: %s", dateStr)
+	}
+	return nano, nil
+}
+
+func (PortableProvider) ParseTime(timeStr string) (int16, error) {
+	return parseTime(timeStr)
+}
+
+func (PortableProvider) ParseDateTime(dateStr, timeStr string) (int64, error) {
+	if len(timeStr) != 5 && len(timeStr) != 8 {
+		return 0, Errf("invalid date/time format: %s %s", dateStr, timeStr)
+	}
+	dt, err := ParseCivilDateTime(dateStr + " " + timeStr)
+	if err != nil {
+		return 0, err
+	}
+	nano, ok := unixNanoOf(dt.localSeconds())
+	if !ok {
+		return 0, Errf("date out of range: %s %s", dateStr, timeStr)
+	}
+	return nano, nil
+}
+
+func (PortableProvider) LocalMinutesToUnixUTC(dateSec int64, localMinutes int, tz string) int64 {
+	loc, err := LoadLocation(tz)
+	if err != nil {
+		loc = UTC
+	}
+	dt := DateTime{Date: DateOfUTC(dateSec * nanosPerSecond)}.Add(int64(localMinutes) * secondsPerMinute * nanosPerSecond)
+	nano, _ := dt.UnixNanoIn(loc, Compatible)
+	return nano / nanosPerSecond
+}
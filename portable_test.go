@@ -0,0 +1,83 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// fixedProvider is a custom runtime: portable formatting and a frozen clock.
+type fixedProvider struct {
+	time.PortableProvider
+	now int64
+}
+
+func (p fixedProvider) UnixNano() int64                      { return p.now }
+func (p fixedProvider) Monotonic() int64                     { return p.now }
+func (p fixedProvider) ZoneOffset(string, int64) (int, bool) { return 0, false }
+func (p fixedProvider) AfterFunc(int, func()) time.Timer     { return nil }
+func (p fixedProvider) TickFunc(int, func()) time.Ticker     { return nil }
+
+// Test that PortableProvider matches the built-in provider and that SetProvider plugs in custom runtimes
+func PortableProviderShared(t *testing.T) {
+	var p time.PortableProvider
+	for _, nano := range []int64{0, 1705329045123456789, -1, 951782400000000000, 4102444799999999999} {
+		checks := [][2]string{
+			{p.FormatDate(nano), time.FormatDate(nano)},
+			{p.FormatTime(nano), time.FormatTime(nano)},
+			{p.FormatDateTime(nano), time.FormatDateTime(nano)},
+			{p.FormatDateTimeShort(nano), time.FormatDateTimeShort(nano)},
+			{p.FormatISO8601(nano), time.FormatISO8601(nano)},
+			{p.FormatCompact(nano), time.FormatCompact(nano)},
+		}
+		for _, c := range checks {
+			if c[0] != c[1] {
+				t.Errorf("portable %q != provider %q for %d", c[0], c[1], nano)
+			}
+		}
+	}
+	for _, v := range []any{"2024-02-29", "2024-02-30", "2024-01-15 10:30:00", "2024-01-15 10:30", int16(570), "10:30", "1705329045000000000", "x"} {
+		if a, b := p.FormatDate(v), time.FormatDate(v); a != b {
+			t.Errorf("FormatDate(%v): portable %q != provider %q", v, a, b)
+		}
+		if a, b := p.FormatTime(v), time.FormatTime(v); a != b {
+			t.Errorf("FormatTime(%v): portable %q != provider %q", v, a, b)
+		}
+		if a, b := p.FormatDateTime(v), time.FormatDateTime(v); a != b {
+			t.Errorf("FormatDateTime(%v): portable %q != provider %q", v, a, b)
+		}
+		if a, b := p.FormatDateTimeShort(v), time.FormatDateTimeShort(v); a != b {
+			t.Errorf("FormatDateTimeShort(%v): portable %q != provider %q", v, a, b)
+		}
+	}
+	for _, s := range []string{"2024-01-15", "1969-12-31", "2024-02-30", "2024-1-15", ""} {
+		a, errA := p.ParseDate(s)
+		b, errB := time.ParseDate(s)
+		if a != b || (errA == nil) != (errB == nil) {
+			t.Errorf("ParseDate(%q): portable %d, %v; provider %d, %v", s, a, errA, b, errB)
+		}
+	}
+	for _, dt := range [][2]string{{"2024-01-15", "10:30"}, {"2024-01-15", "10:30:45"}, {"2024-01-15", "25:00"}, {"bad", "10:30"}} {
+		a, errA := p.ParseDateTime(dt[0], dt[1])
+		b, errB := time.ParseDateTime(dt[0], dt[1])
+		if a != b || (errA == nil) != (errB == nil) {
+			t.Errorf("ParseDateTime(%q): portable %d, %v; provider %d, %v", dt, a, errA, b, errB)
+		}
+	}
+	if got := p.LocalMinutesToUnixUTC(1609459200, 9*60, "UTC"); got != 1609491600 {
+		t.Errorf("LocalMinutesToUnixUTC = %d; want 1609491600", got)
+	}
+
+	time.SetProvider(fixedProvider{now: 1705329045000000000})
+	defer time.SetProvider(nil)
+	if time.Now() != 1705329045000000000 || time.FormatDateTime(time.Now()) != "2024-01-15 14:30:45" {
+		t.Errorf("custom provider: Now = %d, %s", time.Now(), time.FormatDateTime(time.Now()))
+	}
+	if _, err := time.LoadLocation("America/Santiago"); err == nil {
+		t.Error("custom provider without zones should reject IANA names")
+	}
+	time.SetProvider(nil)
+	if time.Now() == 1705329045000000000 {
+		t.Error("SetProvider(nil) should restore the default provider")
+	}
+}
@@ -0,0 +1,84 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Golden values shared by every platform: formatting truncates toward the
+// earlier second, also before 1970, and parsing round-trips exactly.
+func PrecisionShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+
+	golden := []struct {
+		nano       int64
+		utc, local string // offset 0 and UTC-3
+	}{
+		{1705329045999999999, "2024-01-15 14:30:45", "2024-01-15 11:30:45"},
+		{-1, "1969-12-31 23:59:59", "1969-12-31 20:59:59"},
+		{1, "1970-01-01 00:00:00", "1969-12-31 21:00:00"},
+		{-86399999999999, "1969-12-31 00:00:00", "1969-12-30 21:00:00"},
+		{951782400000000001, "2000-02-29 00:00:00", "2000-02-28 21:00:00"},
+		{4102444799999999999, "2099-12-31 23:59:59", "2099-12-31 20:59:59"},
+		{-9000000000000000000, "1684-10-19 08:00:00", "1684-10-19 05:00:00"},
+		{9000000000000000000, "2255-03-14 16:00:00", "2255-03-14 13:00:00"},
+	}
+	for _, g := range golden {
+		time.SetTimeZoneOffset(0)
+		if got := time.FormatDateTime(g.nano); got != g.utc {
+			t.Errorf("FormatDateTime(%d) = %q; want %q", g.nano, got, g.utc)
+		}
+		if got := time.FormatDate(g.nano); got != g.utc[:10] {
+			t.Errorf("FormatDate(%d) = %q; want %q", g.nano, got, g.utc[:10])
+		}
+		if got := time.FormatTime(g.nano); got != g.utc[11:] {
+			t.Errorf("FormatTime(%d) = %q; want %q", g.nano, got, g.utc[11:])
+		}
+		if got := time.FormatDateTimeShort(g.nano); got != g.utc[:16] {
+			t.Errorf("FormatDateTimeShort(%d) = %q; want %q", g.nano, got, g.utc[:16])
+		}
+		iso := g.utc[:10] + "T" + g.utc[11:] + "Z"
+		if got := time.FormatISO8601(g.nano); got != iso {
+			t.Errorf("FormatISO8601(%d) = %q; want %q", g.nano, got, iso)
+		}
+		compact := g.utc[0:4] + g.utc[5:7] + g.utc[8:10] + g.utc[11:13] + g.utc[14:16] + g.utc[17:19]
+		if got := time.FormatCompact(g.nano); got != compact {
+			t.Errorf("FormatCompact(%d) = %q; want %q", g.nano, got, compact)
+		}
+
+		// Whole seconds parse back exactly.
+		sec := g.nano - (g.nano%1e9+1e9)%1e9
+		if parsed, err := time.ParseDateTime(g.utc[:10], g.utc[11:]); err != nil || parsed != sec {
+			t.Errorf("ParseDateTime(%q) = %d, %v; want %d", g.utc, parsed, err, sec)
+		}
+
+		time.SetTimeZoneOffset(-3)
+		if got := time.FormatDateTime(g.nano); got != g.local {
+			t.Errorf("FormatDateTime(%d) at UTC-3 = %q; want %q", g.nano, got, g.local)
+		}
+		if got := time.FormatISO8601(g.nano); got != iso {
+			t.Errorf("FormatISO8601(%d) should ignore the offset: %q", g.nano, got)
+		}
+	}
+
+	time.SetTimeZoneOffset(0)
+	if got := time.FormatTime("1705329045999999999"); got != "14:30:45" {
+		t.Errorf("FormatTime(string nano) = %q", got)
+	}
+	if d, err := time.ParseDate("1969-12-31"); err != nil || d != -86400e9 {
+		t.Errorf("ParseDate(1969-12-31) = %d, %v", d, err)
+	}
+	for _, bad := range []string{"2024-02-30", "2023-02-29", "2024-13-01"} {
+		if _, err := time.ParseDate(bad); err == nil {
+			t.Errorf("ParseDate(%q) should fail", bad)
+		}
+		if got := time.FormatDate(bad); got != "" {
+			t.Errorf("FormatDate(%q) = %q; want rejected", bad, got)
+		}
+	}
+	if _, err := time.ParseDateTime("2024-01-15", "24:00"); err == nil {
+		t.Error("ParseDateTime should reject 24:00")
+	}
+}
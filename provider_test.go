@@ -30,4 +30,29 @@ func RunAPITests(t *testing.T) {
 	t.Run("Weekday", func(t *testing.T) { WeekdayShared(t) })
 	t.Run("MidnightUTC", func(t *testing.T) { MidnightUTCShared(t) })
 	t.Run("LocalMinutesToUnixUTC", func(t *testing.T) { LocalMinutesToUnixUTCShared(t) })
+	t.Run("Date", func(t *testing.T) { DateShared(t) })
+	t.Run("DateTime", func(t *testing.T) { DateTimeShared(t) })
+	t.Run("Location", func(t *testing.T) { LocationShared(t) })
+	t.Run("Names", func(t *testing.T) { NamesShared(t) })
+	t.Run("TimeValue", func(t *testing.T) { TimeValueShared(t) })
+	t.Run("AddDate", func(t *testing.T) { AddDateShared(t) })
+	t.Run("Between", func(t *testing.T) { BetweenShared(t) })
+	t.Run("Bounds", func(t *testing.T) { BoundsShared(t) })
+	t.Run("ISOWeek", func(t *testing.T) { ISOWeekShared(t) })
+	t.Run("Calendar", func(t *testing.T) { CalendarShared(t) })
+	t.Run("Business", func(t *testing.T) { BusinessShared(t) })
+	t.Run("Holidays", func(t *testing.T) { HolidaysShared(t) })
+	t.Run("WorkCalendar", func(t *testing.T) { WorkCalendarShared(t) })
+	t.Run("Slots", func(t *testing.T) { SlotsShared(t) })
+	t.Run("Interval", func(t *testing.T) { IntervalShared(t) })
+	t.Run("RRule", func(t *testing.T) { RRuleShared(t) })
+	t.Run("ICalendar", func(t *testing.T) { ICalendarShared(t) })
+	t.Run("Cron", func(t *testing.T) { CronShared(t) })
+	t.Run("Scheduler", func(t *testing.T) { SchedulerShared(t) })
+	t.Run("Clock", func(t *testing.T) { ClockShared(t) })
+	t.Run("PortableProvider", func(t *testing.T) { PortableProviderShared(t) })
+	t.Run("Stopwatch", func(t *testing.T) { StopwatchShared(t) })
+	t.Run("Precision", func(t *testing.T) { PrecisionShared(t) })
+	t.Run("Range", func(t *testing.T) { RangeShared(t) })
+	t.Run("TickerPeriod", func(t *testing.T) { TickerPeriodShared(t) })
 }
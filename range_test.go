@@ -0,0 +1,123 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test the limits of UnixNano and civil years outside 0000-9999
+func RangeShared(t *testing.T) {
+	initialOffset := time.GetTimeZoneOffset()
+	defer time.SetTimeZoneOffset(initialOffset)
+
+	const minNano, maxNano = -1 << 63, 1<<63 - 1
+	limits := []struct {
+		nano                 int64
+		utc, west, east, iso string // offsets 0, -3 and +3 hours
+	}{
+		{minNano, "1677-09-21 00:12:43", "1677-09-20 21:12:43", "1677-09-21 03:12:43", "1677-09-21T00:12:43Z"},
+		{maxNano, "2262-04-11 23:47:16", "2262-04-11 20:47:16", "2262-04-12 02:47:16", "2262-04-11T23:47:16Z"},
+	}
+	for _, l := range limits {
+		for _, c := range []struct {
+			offset int
+			want   string
+		}{{0, l.utc}, {-3, l.west}, {3, l.east}} {
+			time.SetTimeZoneOffset(c.offset)
+			if got := time.FormatDateTime(l.nano); got != c.want {
+				t.Errorf("FormatDateTime(%d) at %+d = %q; want %q", l.nano, c.offset, got, c.want)
+			}
+			if got := time.FormatDate(l.nano); got != c.want[:10] {
+				t.Errorf("FormatDate(%d) at %+d = %q; want %q", l.nano, c.offset, got, c.want[:10])
+			}
+		}
+		if got := time.FormatISO8601(l.nano); got != l.iso {
+			t.Errorf("FormatISO8601(%d) = %q; want %q", l.nano, got, l.iso)
+		}
+	}
+	time.SetTimeZoneOffset(0)
+	if got := time.FormatTime("-9223372036854775808"); got != "00:12:43" {
+		t.Errorf("FormatTime(min string) = %q", got)
+	}
+	if got := time.FormatCompact(maxNano); got != "22620411234716" {
+		t.Errorf("FormatCompact(max) = %q", got)
+	}
+
+	// Parsing is defined up to the limits and fails beyond them on every platform.
+	parses := []struct {
+		date, clock string
+		want        int64
+		ok          bool
+	}{
+		{"2262-04-11", "23:47:16", 9223372036000000000, true},
+		{"2262-04-11", "23:47:17", 0, false},
+		{"1677-09-21", "00:12:44", -9223372036000000000, true},
+		{"1677-09-21", "00:12:43", 0, false},
+		{"0001-01-01", "00:00", 0, false},
+		{"9999-12-31", "23:59", 0, false},
+	}
+	for _, p := range parses {
+		got, err := time.ParseDateTime(p.date, p.clock)
+		if (err == nil) != p.ok || got != p.want {
+			t.Errorf("ParseDateTime(%s %s) = %d, %v; want %d, ok=%v", p.date, p.clock, got, err, p.want, p.ok)
+		}
+	}
+	for date, ok := range map[string]bool{"1677-09-21": false, "1677-09-22": true, "2262-04-11": true, "2262-04-12": false, "0000-01-01": false} {
+		if _, err := time.ParseDate(date); (err == nil) != ok {
+			t.Errorf("ParseDate(%s) error = %v; want ok=%v", date, err, ok)
+		}
+	}
+
+	// Civil values use the ISO 8601 expanded year outside 0000-9999.
+	years := map[int]string{0: "0000-01-01", 9999: "9999-01-01", 10000: "+010000-01-01", -1: "-000001-01-01", -44: "-000044-01-01", 1234567: "+1234567-01-01"}
+	for year, want := range years {
+		d := time.Date{Year: year, Month: time.January, Day: 1}
+		if got := d.String(); got != want {
+			t.Errorf("Date{%d}.String() = %q; want %q", year, got, want)
+		}
+		if back, err := time.ParseCivilDate(want); err != nil || back != d {
+			t.Errorf("ParseCivilDate(%q) = %v, %v", want, back, err)
+		}
+	}
+	dt, err := time.ParseCivilDateTime("-000044-03-15T12:30:00")
+	if err != nil || dt.String() != "-000044-03-15 12:30:00" || dt.Date.Year != -44 {
+		t.Errorf("ParseCivilDateTime(-000044) = %v, %v", dt, err)
+	}
+	if d, err := time.ParseCivilDate("+2024-02-29"); err != nil || d.String() != "2024-02-29" {
+		t.Errorf("ParseCivilDate(+2024-02-29) = %v, %v", d, err)
+	}
+	for _, bad := range []string{"12024-01-01", "+024-01-01", "*002024-01-01", "-000001-02-30", "+0000000000001-01-01"} {
+		if _, err := time.ParseCivilDate(bad); err == nil {
+			t.Errorf("ParseCivilDate(%q) should fail", bad)
+		}
+	}
+	if got := (time.Date{Year: 10000, Month: time.January, Day: 3}).ISOWeekString(); got != "+010000-W01-1" {
+		t.Errorf("ISOWeekString = %q", got)
+	}
+
+	// Conversions saturate, or fail when they can report errors.
+	if got := (time.Date{Year: 100000, Month: time.January, Day: 1}).UnixNanoUTC(); got != maxNano {
+		t.Errorf("UnixNanoUTC(year 100000) = %d; want max int64", got)
+	}
+	if got := (time.Date{Year: 1000, Month: time.January, Day: 1}).UnixNano(); got != minNano {
+		t.Errorf("UnixNano(year 1000) = %d; want min int64", got)
+	}
+	if got := (time.DateTime{Date: time.Date{Year: 2262, Month: time.April, Day: 12}}).UnixNanoOffset(3 * 3600); got != 9223372036000000000-(23*3600+47*60+16-21*3600)*1e9 {
+		t.Errorf("UnixNanoOffset near max = %d", got)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, year := range []int{-300000, 1000, 3000, 300000} {
+		far := time.DateTime{Date: time.Date{Year: year, Month: time.July, Day: 1}}
+		if _, err := far.UnixNanoIn(ny, time.Compatible); err == nil {
+			t.Errorf("UnixNanoIn(year %d) should be out of range", year)
+		}
+	}
+	inside := time.DateTime{Date: time.Date{Year: 2262, Month: time.April, Day: 11}, Time: time.TimeOfDay{Hour: 12}}
+	if got, err := inside.UnixNanoIn(ny, time.Compatible); err != nil || time.FormatISO8601(got) != "2262-04-11T16:00:00Z" {
+		t.Errorf("UnixNanoIn(2262-04-11 12:00 New York) = %s, %v", time.FormatISO8601(got), err)
+	}
+}
@@ -0,0 +1,198 @@
+package time
+
+import (
+	"slices"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Recurrence is an RFC 5545 recurrence set: DTSTART, an optional RRULE,
+// extra RDATE instants and excluded EXDATE instants, expanded in a location.
+// DTSTART is always the first occurrence and counts towards COUNT.
+type Recurrence struct {
+	Start    DateTime  // DTSTART wall time
+	Location *Location // nil means Local
+	Rule     *RRule    // nil for a set made only of DTSTART and RDATEs
+	RDates   []int64   // UnixNano
+	ExDates  []int64   // UnixNano; exact instants are excluded
+}
+
+// RecurrenceIterator yields the occurrences of a Recurrence in order. It
+// computes one period at a time, so unbounded rules are safe to iterate.
+type RecurrenceIterator struct {
+	rule    *ruleIter
+	rdates  []int64
+	exdates map[int64]bool
+	peek    int64
+	hasPeek bool
+	last    int64
+	started bool
+}
+
+// Iterator returns a lazy iterator over r's occurrences as UnixNano.
+func (r *Recurrence) Iterator() *RecurrenceIterator {
+	rule := RRule{Freq: Daily, Count: 1} // DTSTART only
+	if r.Rule != nil {
+		rule = *r.Rule
+	}
+	it := &RecurrenceIterator{
+		rule:    newRuleIter(rule, r.Start, r.Location),
+		rdates:  slices.Clone(r.RDates),
+		exdates: make(map[int64]bool, len(r.ExDates)),
+	}
+	slices.Sort(it.rdates)
+	for _, ex := range r.ExDates {
+		it.exdates[ex] = true
+	}
+	return it
+}
+
+// Next returns the next occurrence, or false when the set is exhausted.
+func (it *RecurrenceIterator) Next() (int64, bool) {
+	for {
+		if !it.hasPeek {
+			it.peek, it.hasPeek = it.rule.next()
+		}
+		var nano int64
+		switch {
+		case it.hasPeek && (len(it.rdates) == 0 || it.peek <= it.rdates[0]):
+			nano, it.hasPeek = it.peek, false
+		case len(it.rdates) > 0:
+			nano, it.rdates = it.rdates[0], it.rdates[1:]
+		default:
+			return 0, false
+		}
+		if (it.started && nano == it.last) || it.exdates[nano] {
+			continue
+		}
+		it.last, it.started = nano, true
+		return nano, true
+	}
+}
+
+// Take returns at most n occurrences.
+func (r *Recurrence) Take(n int) []int64 {
+	var out []int64
+	it := r.Iterator()
+	for len(out) < n {
+		nano, ok := it.Next()
+		if !ok {
+			break
+		}
+		out = append(out, nano)
+	}
+	return out
+}
+
+// Between returns the occurrences in [from, to).
+func (r *Recurrence) Between(from, to int64) []int64 {
+	var out []int64
+	it := r.Iterator()
+	for {
+		nano, ok := it.Next()
+		if !ok || nano >= to {
+			return out
+		}
+		if nano >= from {
+			out = append(out, nano)
+		}
+	}
+}
+
+// After returns the first occurrence strictly after nano.
+func (r *Recurrence) After(nano int64) (int64, bool) {
+	it := r.Iterator()
+	for {
+		next, ok := it.Next()
+		if !ok || next > nano {
+			return next, ok
+		}
+	}
+}
+
+// ParseRecurrence parses DTSTART, RRULE, RDATE and EXDATE content lines, e.g.
+//
+//	DTSTART;TZID=America/Santiago:20240105T090000
+//	RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20241231T235959Z
+//	EXDATE;TZID=America/Santiago:20240108T090000
+//
+// loc is used for floating times when DTSTART has no TZID and no Z suffix.
+func ParseRecurrence(text string, loc *Location) (*Recurrence, error) {
+	r := &Recurrence{Location: loc}
+	type rawDate struct {
+		dt      DateTime
+		utc     bool
+		loc     *Location
+		exclude bool
+	}
+	var pending []rawDate
+	hasStart := false
+	for _, line := range Convert(text).Split("\n") {
+		line = TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		prop, err := parseContentLine(line)
+		if err != nil {
+			return nil, err
+		}
+		name, value := prop.Name, prop.Value
+		var tz *Location
+		if tzid := prop.Param("TZID"); tzid != "" {
+			if tz, err = LoadLocation(tzid); err != nil {
+				return nil, err
+			}
+		}
+		switch name {
+		case "DTSTART":
+			dt, utc, _, err := parseICalDateTime(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Start, hasStart = dt, true
+			if utc {
+				r.Location = UTC
+			} else if tz != nil {
+				r.Location = tz
+			}
+		case "RRULE":
+			rule, err := ParseRRule(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Rule = &rule
+		case "RDATE", "EXDATE":
+			if prop.Param("VALUE") == "PERIOD" {
+				return nil, Errf("unsupported %s value type: PERIOD", name)
+			}
+			for _, v := range Convert(value).Split(",") {
+				dt, utc, _, err := parseICalDateTime(TrimSpace(v))
+				if err != nil {
+					return nil, err
+				}
+				pending = append(pending, rawDate{dt: dt, utc: utc, loc: tz, exclude: name == "EXDATE"})
+			}
+		default:
+			return nil, Errf("unsupported recurrence property: %s", name)
+		}
+	}
+	if !hasStart {
+		return nil, Errf("recurrence without DTSTART")
+	}
+	for _, p := range pending {
+		at := p.loc
+		if at == nil {
+			at = r.Location
+		}
+		nano := p.dt.UnixNanoOffset(0)
+		if !p.utc {
+			nano, _ = p.dt.UnixNanoIn(at, Compatible)
+		}
+		if p.exclude {
+			r.ExDates = append(r.ExDates, nano)
+		} else {
+			r.RDates = append(r.RDates, nano)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,346 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Frequency is the FREQ of an RRULE.
+type Frequency int
+
+const (
+	Secondly Frequency = iota
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = [...]string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// String returns the RFC 5545 name, e.g. "WEEKLY".
+func (f Frequency) String() string {
+	if f < Secondly || f > Yearly {
+		return Sprintf("Frequency(%d)", int(f))
+	}
+	return frequencyNames[f]
+}
+
+// WeekdayNum is a BYDAY entry: a weekday with an optional ordinal, e.g.
+// "2TU" (N=2) or "-1FR" (N=-1). N == 0 means every such weekday.
+type WeekdayNum struct {
+	N   int
+	Day DayOfWeek
+}
+
+var rruleDays = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// String formats w as in BYDAY, e.g. "-1FR".
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return rruleDays[w.Day]
+	}
+	return Sprintf("%d%s", w.N, rruleDays[w.Day])
+}
+
+// RRule is an RFC 5545 recurrence rule. Expand it with a Recurrence, which
+// supplies DTSTART, the location and RDATE / EXDATE.
+type RRule struct {
+	Freq     Frequency
+	Interval int // 0 or 1 means every period
+	Count    int // 0 means unbounded
+	// Until bounds the rule inclusively: a UTC instant when UntilUTC is
+	// set, otherwise a wall time in the recurrence location. The zero
+	// DateTime means no bound.
+	Until      DateTime
+	UntilUTC   bool
+	ByMonth    []int
+	ByWeekNo   []int
+	ByYearDay  []int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	wkst       DayOfWeek // WKST, see WeekStart
+	wkstSet    bool
+	untilDate  bool // UNTIL was a DATE value
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6".
+// A leading "RRULE:" is accepted.
+func ParseRRule(s string) (RRule, error) {
+	var r RRule
+	body := TrimSpace(s)
+	if len(body) >= 6 && ToUpper(body[:6]) == "RRULE:" {
+		body = body[6:]
+	}
+	if body == "" {
+		return r, Errf("invalid RRULE: empty")
+	}
+	freqSet := false
+	for _, part := range Convert(body).Split(";") {
+		eq := -1
+		for i := 0; i < len(part); i++ {
+			if part[i] == '=' {
+				eq = i
+				break
+			}
+		}
+		if eq <= 0 {
+			return r, Errf("invalid RRULE part: %s", part)
+		}
+		key, val := ToUpper(part[:eq]), part[eq+1:]
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq, err = parseFrequency(val)
+			freqSet = err == nil
+		case "INTERVAL":
+			r.Interval, err = rrulePositive(key, val)
+		case "COUNT":
+			r.Count, err = rrulePositive(key, val)
+		case "UNTIL":
+			var dateOnly bool
+			r.Until, r.UntilUTC, dateOnly, err = parseICalDateTime(val)
+			if dateOnly {
+				r.Until.Time = TimeOfDay{Hour: 23, Minute: 59, Second: 59}
+				r.untilDate = true
+			}
+		case "BYMONTH":
+			r.ByMonth, err = rruleInts(key, val, 1, 12, false)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = rruleInts(key, val, 1, 53, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = rruleInts(key, val, 1, 366, true)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = rruleInts(key, val, 1, 31, true)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(val)
+		case "BYHOUR":
+			r.ByHour, err = rruleInts(key, val, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = rruleInts(key, val, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = rruleInts(key, val, 0, 60, false)
+		case "BYSETPOS":
+			r.BySetPos, err = rruleInts(key, val, 1, 366, true)
+		case "WKST":
+			r.wkst, err = parseRRuleDay(val)
+			r.wkstSet = true
+		default:
+			return r, Errf("unsupported RRULE part: %s", key)
+		}
+		if err != nil {
+			return r, err
+		}
+	}
+	if !freqSet {
+		return r, Errf("invalid RRULE: FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return r, Errf("invalid RRULE: COUNT and UNTIL are exclusive")
+	}
+	return r, nil
+}
+
+// String formats r as an RRULE value in canonical part order.
+func (r RRule) String() string {
+	buf := []byte("FREQ=" + r.Freq.String())
+	if r.Interval > 1 {
+		buf = append(buf, Sprintf(";INTERVAL=%d", r.Interval)...)
+	}
+	if r.Count > 0 {
+		buf = append(buf, Sprintf(";COUNT=%d", r.Count)...)
+	}
+	if !r.Until.IsZero() {
+		buf = append(buf, ";UNTIL="...)
+		if r.untilDate {
+			buf = append(buf, formatICalDate(r.Until.Date)...)
+		} else {
+			buf = append(buf, formatICalDateTime(r.Until, r.UntilUTC)...)
+		}
+	}
+	buf = appendRRuleInts(buf, "BYMONTH", r.ByMonth)
+	buf = appendRRuleInts(buf, "BYWEEKNO", r.ByWeekNo)
+	buf = appendRRuleInts(buf, "BYYEARDAY", r.ByYearDay)
+	buf = appendRRuleInts(buf, "BYMONTHDAY", r.ByMonthDay)
+	for i, w := range r.ByDay {
+		if i == 0 {
+			buf = append(buf, ";BYDAY="...)
+		} else {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, w.String()...)
+	}
+	buf = appendRRuleInts(buf, "BYHOUR", r.ByHour)
+	buf = appendRRuleInts(buf, "BYMINUTE", r.ByMinute)
+	buf = appendRRuleInts(buf, "BYSECOND", r.BySecond)
+	buf = appendRRuleInts(buf, "BYSETPOS", r.BySetPos)
+	if r.wkstSet && r.wkst != Monday {
+		buf = append(buf, ";WKST="+rruleDays[r.wkst]...)
+	}
+	return string(buf)
+}
+
+func appendRRuleInts(buf []byte, key string, vals []int) []byte {
+	for i, v := range vals {
+		if i == 0 {
+			buf = append(buf, ';')
+			buf = append(buf, key...)
+			buf = append(buf, '=')
+		} else {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, Sprintf("%d", v)...)
+	}
+	return buf
+}
+
+// WeekStart returns WKST, which defaults to Monday.
+func (r RRule) WeekStart() DayOfWeek {
+	if r.wkstSet {
+		return r.wkst
+	}
+	return Monday
+}
+
+// SetWeekStart sets WKST.
+func (r *RRule) SetWeekStart(d DayOfWeek) {
+	r.wkst, r.wkstSet = d, true
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	up := ToUpper(s)
+	for i, name := range frequencyNames {
+		if name == up {
+			return Frequency(i), nil
+		}
+	}
+	return 0, Errf("invalid RRULE FREQ: %s", s)
+}
+
+func parseRRuleDay(s string) (DayOfWeek, error) {
+	up := ToUpper(s)
+	for i, name := range rruleDays {
+		if name == up {
+			return DayOfWeek(i), nil
+		}
+	}
+	return 0, Errf("invalid RRULE weekday: %s", s)
+}
+
+func parseByDay(val string) ([]WeekdayNum, error) {
+	var out []WeekdayNum
+	for _, item := range Convert(val).Split(",") {
+		if len(item) < 2 {
+			return nil, Errf("invalid BYDAY: %s", item)
+		}
+		day, err := parseRRuleDay(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		if num := item[:len(item)-2]; num != "" {
+			n, err = rruleInt(num)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, Errf("invalid BYDAY: %s", item)
+			}
+		}
+		out = append(out, WeekdayNum{N: n, Day: day})
+	}
+	return out, nil
+}
+
+// rruleInts parses a comma list of integers in [lo, hi]; signed allows -hi..-lo too.
+func rruleInts(key, val string, lo, hi int, signed bool) ([]int, error) {
+	var out []int
+	for _, item := range Convert(val).Split(",") {
+		n, err := rruleInt(item)
+		abs := n
+		if signed && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < lo || abs > hi || (!signed && n < 0) {
+			return nil, Errf("invalid RRULE %s: %s", key, item)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func rrulePositive(key, val string) (int, error) {
+	n, err := rruleInt(val)
+	if err != nil || n < 1 {
+		return 0, Errf("invalid RRULE %s: %s", key, val)
+	}
+	return n, nil
+}
+
+// rruleInt parses an optionally signed decimal integer.
+func rruleInt(s string) (int, error) {
+	sign := 1
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	n, ok := parseDigits(s)
+	if !ok {
+		return 0, Errf("invalid integer: %s", s)
+	}
+	return sign * n, nil
+}
+
+// parseICalDateTime parses an iCalendar DATE ("20240115") or DATE-TIME
+// ("20240115T093000", with a trailing Z for UTC).
+func parseICalDateTime(s string) (dt DateTime, utc, dateOnly bool, err error) {
+	if len(s) == 8 {
+		d, ok := parseICalDate(s)
+		if !ok {
+			return dt, false, false, Errf("invalid iCalendar date: %s", s)
+		}
+		return DateTime{Date: d}, false, true, nil
+	}
+	if len(s) == 16 && s[15] == 'Z' {
+		utc = true
+		s = s[:15]
+	}
+	if len(s) != 15 || s[8] != 'T' {
+		return dt, false, false, Errf("invalid iCalendar date-time: %s", s)
+	}
+	d, ok := parseICalDate(s[:8])
+	h, ok1 := parseDigits(s[9:11])
+	m, ok2 := parseDigits(s[11:13])
+	sec, ok3 := parseDigits(s[13:15])
+	dt = DateTime{Date: d, Time: TimeOfDay{Hour: h, Minute: m, Second: sec}}
+	if !ok || !ok1 || !ok2 || !ok3 || !dt.Time.IsValid() {
+		return DateTime{}, false, false, Errf("invalid iCalendar date-time: %s", s)
+	}
+	return dt, utc, false, nil
+}
+
+func parseICalDate(s string) (Date, bool) {
+	y, ok1 := parseDigits(s[:4])
+	m, ok2 := parseDigits(s[4:6])
+	d, ok3 := parseDigits(s[6:8])
+	date := Date{Year: y, Month: Month(m), Day: d}
+	return date, ok1 && ok2 && ok3 && date.IsValid()
+}
+
+// formatICalDate formats d as "20240115".
+func formatICalDate(d Date) string {
+	return Sprintf("%04d%02d%02d", d.Year, int(d.Month), d.Day)
+}
+
+// formatICalDateTime formats dt as "20240115T093000", with a Z suffix when utc.
+func formatICalDateTime(dt DateTime, utc bool) string {
+	s := formatICalDate(dt.Date) + Sprintf("T%02d%02d%02d", dt.Time.Hour, dt.Time.Minute, dt.Time.Second)
+	if utc {
+		s += "Z"
+	}
+	return s
+}
@@ -0,0 +1,445 @@
+package time
+
+import (
+	"slices"
+)
+
+// maxRecurrenceYear stops expansion of rules that never match again.
+const maxRecurrenceYear = 9999
+
+// maxEmptyPeriods stops expansion after this many consecutive periods
+// without a candidate: the days of a 400-year Gregorian cycle, after which
+// every calendar pattern has repeated.
+const maxEmptyPeriods = 146097
+
+// ruleIter expands an RRule lazily, one period (year, month, week, day,
+// hour, minute or second) at a time. Candidates are wall times in the
+// recurrence location resolved with Compatible, so a time skipped by DST
+// moves forward and a repeated time takes its first occurrence. A skipped
+// time that lands on an instant already produced is dropped before COUNT
+// sees it.
+type ruleIter struct {
+	rule      RRule
+	start     DateTime
+	startNano int64
+	loc       *Location
+	interval  int64
+	wkst      DayOfWeek
+
+	byMonth, byWeekNo, byYearDay, byMonthDay []int
+	byHour, byMinute, bySecond               []int
+	byDay                                    []WeekdayNum
+
+	until    int64
+	hasUntil bool
+
+	period      int64 // index of the next period to expand
+	empty       int   // consecutive periods without candidates
+	unreachable bool  // no period passes the time filters
+	buf         []int64
+	last        int64 // latest instant produced, starting at DTSTART
+	emitted     int
+	done        bool
+}
+
+func newRuleIter(r RRule, start DateTime, loc *Location) *ruleIter {
+	it := &ruleIter{
+		rule:     r,
+		start:    start,
+		loc:      loc,
+		interval: int64(max(r.Interval, 1)),
+		wkst:     r.WeekStart(),
+
+		byMonth:    sortedInts(r.ByMonth),
+		byWeekNo:   r.ByWeekNo,
+		byYearDay:  r.ByYearDay,
+		byMonthDay: r.ByMonthDay,
+		byDay:      r.ByDay,
+		byHour:     sortedInts(r.ByHour),
+		byMinute:   sortedInts(r.ByMinute),
+		bySecond:   leapSecondAsLast(sortedInts(r.BySecond)),
+	}
+	it.startNano, _ = start.UnixNanoIn(loc, Compatible)
+	it.last = it.startNano
+
+	noDayRule := len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0
+	switch {
+	case r.Freq == Yearly && noDayRule:
+		if len(it.byMonth) == 0 {
+			it.byMonth = []int{int(start.Date.Month)}
+		}
+		it.byMonthDay = []int{start.Date.Day}
+	case r.Freq == Monthly && noDayRule:
+		it.byMonthDay = []int{start.Date.Day}
+	case r.Freq == Weekly && noDayRule:
+		it.byDay = []WeekdayNum{{Day: start.Date.Weekday()}}
+	}
+	if r.Freq >= Daily {
+		it.byHour = orDefault(it.byHour, start.Time.Hour)
+	}
+	if r.Freq >= Hourly {
+		it.byMinute = orDefault(it.byMinute, start.Time.Minute)
+	}
+	if r.Freq >= Minutely {
+		it.bySecond = orDefault(it.bySecond, start.Time.Second)
+	}
+
+	// Only DTSTART occurs when the INTERVAL stride never meets the BY* times.
+	it.unreachable = r.Freq < Daily && !it.timeReachable()
+
+	if !r.Until.IsZero() {
+		it.hasUntil = true
+		if r.UntilUTC {
+			it.until = r.Until.UnixNanoOffset(0)
+		} else {
+			it.until, _ = r.Until.UnixNanoIn(loc, Compatible)
+		}
+	}
+	return it
+}
+
+// next returns the next occurrence; DTSTART is always the first one.
+func (it *ruleIter) next() (int64, bool) {
+	if it.done || (it.rule.Count > 0 && it.emitted >= it.rule.Count) {
+		it.done = true
+		return 0, false
+	}
+	if it.emitted == 0 {
+		it.emitted++
+		if it.hasUntil && it.startNano > it.until {
+			it.done = true
+			return 0, false
+		}
+		return it.startNano, true
+	}
+	for len(it.buf) == 0 {
+		if it.done {
+			return 0, false
+		}
+		it.expand()
+	}
+	nano := it.buf[0]
+	it.buf = it.buf[1:]
+	it.emitted++
+	return nano, true
+}
+
+// expand fills buf with the occurrences of the current period and advances it.
+func (it *ruleIter) expand() {
+	n := it.period
+	it.period++
+	days, fixed := it.periodDays(n)
+	if it.unreachable || len(days) == 0 || days[0].Year > maxRecurrenceYear {
+		it.done = true
+		return
+	}
+
+	var set []DateTime
+	for _, d := range days {
+		switch {
+		case fixed == nil:
+			if it.matchDay(d) {
+				set = it.appendTimes(set, d, nil)
+			}
+		case !it.matchDay(d):
+			it.skipToNextDay(n)
+		case !it.timeMatches(*fixed):
+			it.skipToNextTime(n)
+		default:
+			set = it.appendTimes(set, d, fixed)
+		}
+	}
+	if len(set) == 0 {
+		if it.empty++; it.empty > maxEmptyPeriods {
+			it.done = true
+		}
+		return
+	}
+	it.empty = 0
+	if len(it.rule.BySetPos) > 0 {
+		set = applySetPos(set, it.rule.BySetPos)
+	}
+	// Times in a DST gap move forward and may collide with, or pass, later
+	// wall times of the period: sort and drop instants already produced.
+	nanos := make([]int64, len(set))
+	for i, dt := range set {
+		nanos[i], _ = dt.UnixNanoIn(it.loc, Compatible)
+	}
+	slices.Sort(nanos)
+	for _, nano := range nanos {
+		if nano <= it.last {
+			continue
+		}
+		if it.hasUntil && nano > it.until {
+			it.done = true
+			return
+		}
+		it.buf = append(it.buf, nano)
+		it.last = nano
+	}
+}
+
+// periodDays returns the days of period n. For sub-daily frequencies it
+// returns the single day plus the period's wall time in fixed.
+func (it *ruleIter) periodDays(n int64) ([]Date, *TimeOfDay) {
+	step := n * it.interval
+	start := it.start.Date
+	switch it.rule.Freq {
+	case Yearly:
+		y := start.Year + int(step)
+		if y > maxRecurrenceYear {
+			return nil, nil
+		}
+		from, to := Date{Year: y, Month: January, Day: 1}, Date{Year: y + 1, Month: January, Day: 1}
+		if len(it.byWeekNo) > 0 {
+			from, to = weekOneStart(y, it.wkst), weekOneStart(y+1, it.wkst)
+		}
+		return dateRange(from, to), nil
+	case Monthly:
+		mi := int64(start.Year)*12 + int64(start.Month) - 1 + step
+		y, m := int(floorDiv(mi, 12)), Month(floorMod(mi, 12)+1)
+		first := Date{Year: y, Month: m, Day: 1}
+		return dateRange(first, first.AddMonths(1)), nil
+	case Weekly:
+		first := start.AddDays(-int(start.Weekday().Add(-int(it.wkst)))).AddDays(int(step) * 7)
+		return dateRange(first, first.AddDays(7)), nil
+	case Daily:
+		return []Date{start.AddDays(int(step))}, nil
+	}
+	base, unit := it.subDailyBase()
+	dt := dateTimeFromLocal(base+step*unit, 0)
+	return []Date{dt.Date}, &dt.Time
+}
+
+// subDailyBase returns DTSTART truncated to the frequency unit in wall seconds, and the unit.
+func (it *ruleIter) subDailyBase() (int64, int64) {
+	unit := int64(1)
+	switch it.rule.Freq {
+	case Hourly:
+		unit = secondsPerHour
+	case Minutely:
+		unit = secondsPerMinute
+	}
+	sec, _ := it.start.localSeconds()
+	return sec - floorMod(sec, unit), unit
+}
+
+// skipToNextDay moves a sub-daily iteration to the first period of the next day.
+func (it *ruleIter) skipToNextDay(n int64) {
+	base, unit := it.subDailyBase()
+	stride := it.interval * unit
+	next := floorDiv(base+n*stride, secondsPerDay)*secondsPerDay + secondsPerDay
+	if p := (next - base + stride - 1) / stride; p > it.period {
+		it.period = p
+	}
+}
+
+// skipToNextTime moves a sub-daily iteration to the next period of the same
+// day whose wall time passes timeMatches, or to the next day.
+func (it *ruleIter) skipToNextTime(n int64) {
+	base, unit := it.subDailyBase()
+	stride := it.interval * unit
+	dayEnd := floorDiv(base+n*stride, secondsPerDay)*secondsPerDay + secondsPerDay
+	for p := n + 1; base+p*stride < dayEnd; p++ {
+		if it.timeMatches(wallTime(base + p*stride)) {
+			it.period = max(it.period, p)
+			return
+		}
+	}
+	it.skipToNextDay(n)
+}
+
+// timeReachable reports whether a sub-daily rule has any period whose wall
+// time passes timeMatches. Period times modulo a day are exactly the
+// multiples of gcd(stride, day) offset from DTSTART.
+func (it *ruleIter) timeReachable() bool {
+	base, unit := it.subDailyBase()
+	g := gcd(it.interval*unit, secondsPerDay)
+	for v := floorMod(base, g); v < secondsPerDay; v += g {
+		if it.timeMatches(wallTime(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeMatches reports whether the wall time of a sub-daily period passes
+// the BYHOUR, BYMINUTE and BYSECOND filters at or above its frequency.
+func (it *ruleIter) timeMatches(t TimeOfDay) bool {
+	f := it.rule.Freq
+	return (len(it.byHour) == 0 || slices.Contains(it.byHour, t.Hour)) &&
+		(f > Minutely || len(it.byMinute) == 0 || slices.Contains(it.byMinute, t.Minute)) &&
+		(f > Secondly || len(it.bySecond) == 0 || slices.Contains(it.bySecond, t.Second))
+}
+
+// wallTime returns the time of day of local seconds since the epoch.
+func wallTime(local int64) TimeOfDay {
+	return timeOfDayFromNanos(floorMod(local, secondsPerDay) * nanosPerSecond)
+}
+
+// appendTimes appends the wall times of day d to set; for sub-daily
+// frequencies fixed is the period's time, already checked by timeMatches.
+func (it *ruleIter) appendTimes(set []DateTime, d Date, fixed *TimeOfDay) []DateTime {
+	nsec := it.start.Time.Nanosecond
+	hours, minutes, seconds := it.byHour, it.byMinute, it.bySecond
+	if fixed != nil {
+		hours = []int{fixed.Hour}
+		if it.rule.Freq <= Minutely {
+			minutes = []int{fixed.Minute}
+		}
+		if it.rule.Freq == Secondly {
+			seconds = []int{fixed.Second}
+		}
+	}
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				set = append(set, DateTime{Date: d, Time: TimeOfDay{Hour: h, Minute: m, Second: s, Nanosecond: nsec}})
+			}
+		}
+	}
+	return set
+}
+
+// matchDay applies the BYMONTH, BYWEEKNO, BYYEARDAY, BYMONTHDAY and BYDAY filters.
+func (it *ruleIter) matchDay(d Date) bool {
+	if len(it.byMonth) > 0 && !slices.Contains(it.byMonth, int(d.Month)) {
+		return false
+	}
+	if len(it.byWeekNo) > 0 {
+		wy, wk := weekNumber(d, it.wkst)
+		if !matchOrdinal(it.byWeekNo, wk, weeksInWeekYear(wy, it.wkst)) {
+			return false
+		}
+	}
+	if len(it.byYearDay) > 0 && !matchOrdinal(it.byYearDay, d.YearDay(), DaysInYear(d.Year)) {
+		return false
+	}
+	if len(it.byMonthDay) > 0 && !matchOrdinal(it.byMonthDay, d.Day, daysInMonth(d.Year, int(d.Month))) {
+		return false
+	}
+	if len(it.byDay) == 0 {
+		return true
+	}
+	inMonth := it.rule.Freq == Monthly || (it.rule.Freq == Yearly && len(it.byMonth) > 0)
+	inYear := it.rule.Freq == Yearly && !inMonth && len(it.byWeekNo) == 0
+	wd := d.Weekday()
+	for _, w := range it.byDay {
+		if w.Day != wd {
+			continue
+		}
+		switch {
+		case w.N == 0:
+			return true
+		case inMonth:
+			dim := daysInMonth(d.Year, int(d.Month))
+			if w.N == (d.Day-1)/7+1 || w.N == -((dim-d.Day)/7+1) {
+				return true
+			}
+		case inYear:
+			yd, diy := d.YearDay(), DaysInYear(d.Year)
+			if w.N == (yd-1)/7+1 || w.N == -((diy-yd)/7+1) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrdinal reports whether v (1-based, out of total) matches a positive or negative ordinal.
+func matchOrdinal(list []int, v, total int) bool {
+	for _, n := range list {
+		if n == v || (n < 0 && total+n+1 == v) {
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos keeps the BYSETPOS positions of the sorted period set.
+func applySetPos(set []DateTime, pos []int) []DateTime {
+	keep := make([]bool, len(set))
+	for _, p := range pos {
+		i := p - 1
+		if p < 0 {
+			i = len(set) + p
+		}
+		if i >= 0 && i < len(set) {
+			keep[i] = true
+		}
+	}
+	out := set[:0]
+	for i, dt := range set {
+		if keep[i] {
+			out = append(out, dt)
+		}
+	}
+	return out
+}
+
+// weekOneStart returns the first day of week 1 of year: the week starting
+// on wkst that has at least four days in the year (ISO 8601 when wkst is Monday).
+func weekOneStart(year int, wkst DayOfWeek) Date {
+	jan1 := Date{Year: year, Month: January, Day: 1}
+	off := int(jan1.Weekday().Add(-int(wkst)))
+	start := jan1.AddDays(-off)
+	if off > 3 {
+		start = start.AddDays(7)
+	}
+	return start
+}
+
+// weekNumber returns the week-year and week of d for weeks starting on wkst.
+func weekNumber(d Date, wkst DayOfWeek) (int, int) {
+	year := d.Year
+	if next := weekOneStart(year+1, wkst); !d.Before(next) {
+		year++
+	} else if d.Before(weekOneStart(year, wkst)) {
+		year--
+	}
+	return year, d.DaysSince(weekOneStart(year, wkst))/7 + 1
+}
+
+func weeksInWeekYear(year int, wkst DayOfWeek) int {
+	return weekOneStart(year+1, wkst).DaysSince(weekOneStart(year, wkst)) / 7
+}
+
+// dateRange returns the dates in [from, to).
+func dateRange(from, to Date) []Date {
+	out := make([]Date, 0, to.DaysSince(from))
+	for d := from; d.Before(to); d = d.AddDays(1) {
+		out = append(out, d)
+	}
+	return out
+}
+
+func sortedInts(v []int) []int {
+	out := slices.Clone(v)
+	slices.Sort(out)
+	return out
+}
+
+// leapSecondAsLast maps BYSECOND=60 to 59: wall times have no leap second,
+// so the last second of the minute stands in for it.
+func leapSecondAsLast(v []int) []int {
+	for i, s := range v {
+		v[i] = min(s, 59)
+	}
+	return slices.Compact(v)
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func orDefault(v []int, def int) []int {
+	if len(v) == 0 {
+		return []int{def}
+	}
+	return v
+}
@@ -0,0 +1,194 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test RRULE parsing and expansion (RFC 5545 section 3.8.5.3 examples)
+func RRuleShared(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expand := func(start, rule string, n int) []string {
+		t.Helper()
+		dt, err := time.ParseCivilDateTime(start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := time.ParseRRule(rule)
+		if err != nil {
+			t.Fatalf("ParseRRule(%s): %v", rule, err)
+		}
+		rec := time.Recurrence{Start: dt, Location: ny, Rule: &r}
+		var out []string
+		for _, nano := range rec.Take(n) {
+			out = append(out, time.TimeOf(nano).In(ny).Format("2006-01-02 15:04"))
+		}
+		return out
+	}
+	check := func(name string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s = %v; want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v; want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	check("daily count", expand("1997-09-02 09:00", "FREQ=DAILY;COUNT=3", 10),
+		"1997-09-02 09:00", "1997-09-03 09:00", "1997-09-04 09:00")
+	check("weekly until", expand("1997-09-02 09:00", "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH", 20),
+		"1997-09-02 09:00", "1997-09-04 09:00", "1997-09-09 09:00", "1997-09-11 09:00", "1997-09-16 09:00",
+		"1997-09-18 09:00", "1997-09-23 09:00", "1997-09-25 09:00", "1997-09-30 09:00", "1997-10-02 09:00")
+	check("every other Tuesday", expand("1997-09-02 09:00", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", 4),
+		"1997-09-02 09:00", "1997-09-16 09:00", "1997-09-30 09:00", "1997-10-14 09:00")
+	check("first Friday across DST", expand("1997-09-05 09:00", "FREQ=MONTHLY;COUNT=10;BYDAY=1FR", 4),
+		"1997-09-05 09:00", "1997-10-03 09:00", "1997-11-07 09:00", "1997-12-05 09:00")
+	check("last weekday", expand("1997-09-30 09:00", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", 6),
+		"1997-09-30 09:00", "1997-10-31 09:00", "1997-11-28 09:00", "1997-12-31 09:00", "1998-01-30 09:00", "1998-02-27 09:00")
+	check("third Tu/We/Th", expand("1997-09-04 09:00", "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3", 5),
+		"1997-09-04 09:00", "1997-10-07 09:00", "1997-11-06 09:00")
+	check("week 20 Monday", expand("1997-05-12 09:00", "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", 3),
+		"1997-05-12 09:00", "1998-05-11 09:00", "1999-05-17 09:00")
+	check("20th Monday", expand("1997-05-19 09:00", "FREQ=YEARLY;BYDAY=20MO", 3),
+		"1997-05-19 09:00", "1998-05-18 09:00", "1999-05-17 09:00")
+	check("June and July", expand("1997-06-10 09:00", "FREQ=YEARLY;COUNT=10;BYMONTH=6,7", 4),
+		"1997-06-10 09:00", "1997-07-10 09:00", "1998-06-10 09:00", "1998-07-10 09:00")
+	check("month day -1", expand("2024-01-31 08:00", "FREQ=MONTHLY;BYMONTHDAY=-1", 3),
+		"2024-01-31 08:00", "2024-02-29 08:00", "2024-03-31 08:00")
+	check("month day 31 skips", expand("2024-01-31 08:00", "FREQ=MONTHLY", 3),
+		"2024-01-31 08:00", "2024-03-31 08:00", "2024-05-31 08:00")
+	check("WKST=MO", expand("1997-08-05 09:00", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", 5),
+		"1997-08-05 09:00", "1997-08-10 09:00", "1997-08-19 09:00", "1997-08-24 09:00")
+	check("WKST=SU", expand("1997-08-05 09:00", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", 5),
+		"1997-08-05 09:00", "1997-08-17 09:00", "1997-08-19 09:00", "1997-08-31 09:00")
+	check("hourly until", expand("1997-09-02 09:00", "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T210000Z", 10),
+		"1997-09-02 09:00", "1997-09-02 12:00", "1997-09-02 15:00")
+	check("daily by hour and minute", expand("1997-09-02 09:00", "FREQ=DAILY;BYHOUR=9,10;BYMINUTE=0,20,40", 7),
+		"1997-09-02 09:00", "1997-09-02 09:20", "1997-09-02 09:40", "1997-09-02 10:00",
+		"1997-09-02 10:20", "1997-09-02 10:40", "1997-09-03 09:00")
+	check("minutely by hour", expand("1997-09-02 16:00", "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16", 5),
+		"1997-09-02 16:00", "1997-09-02 16:20", "1997-09-02 16:40", "1997-09-03 09:00", "1997-09-03 09:20")
+	check("hourly stride crossing days", expand("1997-09-02 10:00", "FREQ=HOURLY;INTERVAL=5;BYHOUR=1", 3),
+		"1997-09-02 10:00", "1997-09-03 01:00", "1997-09-08 01:00")
+
+	// Rules whose INTERVAL stride never meets the BY* filters end after
+	// DTSTART instead of spinning until year 9999.
+	for _, rule := range []string{
+		"FREQ=HOURLY;INTERVAL=2;BYHOUR=1",
+		"FREQ=MINUTELY;INTERVAL=2;BYMINUTE=1",
+		"FREQ=SECONDLY;INTERVAL=2;BYSECOND=1",
+		"FREQ=HOURLY;INTERVAL=168;BYDAY=TU",
+		"FREQ=DAILY;INTERVAL=7;BYDAY=TU",
+	} {
+		r, _ := time.ParseRRule(rule)
+		never := time.Recurrence{Start: time.DateTime{Date: time.Date{Year: 2024, Month: 1, Day: 1}, Time: time.TimeOfDay{Hour: 10}}, Location: time.UTC, Rule: &r}
+		start := time.Date{Year: 2024, Month: 1, Day: 1}.UnixNanoUTC() + 10*3600*1000000000
+		if got := never.Take(3); len(got) != 1 || got[0] != start {
+			t.Errorf("%s: Take(3) = %v; want only DTSTART", rule, got)
+		}
+		if _, ok := never.After(start); ok {
+			t.Errorf("%s: After(DTSTART) should find nothing", rule)
+		}
+		if got := never.Between(start, start+3650*86400*1000000000); len(got) != 1 {
+			t.Errorf("%s: Between = %d occurrences; want 1", rule, len(got))
+		}
+	}
+
+	// BYSECOND=60 (a leap second) stands for the last second of the minute.
+	leap, err := time.ParseRRule("FREQ=SECONDLY;BYSECOND=60")
+	if err != nil || leap.String() != "FREQ=SECONDLY;BYSECOND=60" {
+		t.Fatalf("ParseRRule(BYSECOND=60) = %v, %v", leap, err)
+	}
+	for _, r := range []string{"FREQ=SECONDLY;BYSECOND=60", "FREQ=MINUTELY;BYSECOND=59,60"} {
+		leap, _ = time.ParseRRule(r)
+		lr := time.Recurrence{Start: time.DateTime{Date: time.Date{Year: 2024, Month: 1, Day: 1}, Time: time.TimeOfDay{Hour: 9}}, Location: time.UTC, Rule: &leap}
+		var got []string
+		for _, nano := range lr.Take(3) {
+			got = append(got, time.TimeOf(nano).UTC().Format("15:04:05"))
+		}
+		check(r, got, "09:00:00", "09:00:59", "09:01:59")
+	}
+
+	// Friday the 13th, excluding the unsynchronised DTSTART
+	f13, err := time.ParseRecurrence(`DTSTART;TZID=America/New_York:19970902T090000
+EXDATE;TZID=America/New_York:19970902T090000
+RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, nano := range f13.Take(5) {
+		got = append(got, time.TimeOf(nano).In(ny).Format("2006-01-02"))
+	}
+	check("Friday 13th", got, "1998-02-13", "1998-03-13", "1998-11-13", "1999-08-13", "2000-10-13")
+
+	// RDATE merged in order, EXDATE removes an occurrence, UTC values
+	rec, err := time.ParseRecurrence("DTSTART:20240101T120000Z\nRRULE:FREQ=DAILY;COUNT=4\nRDATE:20240102T180000Z,20240110T120000Z\nEXDATE:20240103T120000Z", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, nano := range rec.Take(10) {
+		got = append(got, time.FormatISO8601(nano))
+	}
+	check("RDATE/EXDATE", got, "2024-01-01T12:00:00Z", "2024-01-02T12:00:00Z", "2024-01-02T18:00:00Z",
+		"2024-01-04T12:00:00Z", "2024-01-10T12:00:00Z")
+	if n, ok := rec.After(time.Date{Year: 2024, Month: 1, Day: 4}.UnixNanoUTC() + 12*3600*1000000000); !ok || time.FormatISO8601(n) != "2024-01-10T12:00:00Z" {
+		t.Errorf("After = %s, %v", time.FormatISO8601(n), ok)
+	}
+
+	// Nonexistent local times move forward (Santiago skips 2024-09-08 00:00-01:00)
+	scl, _ := time.LoadLocation("America/Santiago")
+	daily, _ := time.ParseRRule("FREQ=DAILY")
+	sr := time.Recurrence{Start: time.DateTime{Date: time.Date{Year: 2024, Month: 9, Day: 7}, Time: time.TimeOfDay{Minute: 30}}, Location: scl, Rule: &daily}
+	got = nil
+	for _, nano := range sr.Take(3) {
+		got = append(got, time.TimeOf(nano).In(scl).Format("01-02 15:04 -07:00"))
+	}
+	check("DST gap", got, "09-07 00:30 -04:00", "09-08 01:30 -03:00", "09-09 00:30 -03:00")
+
+	// A gap time that resolves onto the next occurrence does not use up COUNT
+	hourly, _ := time.ParseRRule("FREQ=HOURLY;COUNT=5")
+	hr := time.Recurrence{Start: time.DateTime{Date: time.Date{Year: 2024, Month: 3, Day: 10}}, Location: ny, Rule: &hourly}
+	got = nil
+	for _, nano := range hr.Take(10) {
+		got = append(got, time.TimeOf(nano).In(ny).Format("15:04 -07:00"))
+	}
+	check("COUNT across DST gap", got, "00:00 -05:00", "01:00 -05:00", "03:00 -04:00", "04:00 -04:00", "05:00 -04:00")
+
+	// Unbounded rules are lazy: Between only expands the needed periods
+	weekly, _ := time.ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	lazy := time.Recurrence{Start: time.DateTime{Date: time.Date{Year: 2024, Month: 1, Day: 1}, Time: time.TimeOfDay{Hour: 9}}, Location: time.UTC, Rule: &weekly}
+	from := time.Date{Year: 2024, Month: 5, Day: 20}.UnixNanoUTC()
+	if got := lazy.Between(from, from+7*86400*1000000000); len(got) != 3 {
+		t.Errorf("Between(one week) = %d occurrences; want 3", len(got))
+	}
+
+	// Parsing and formatting
+	for _, s := range []string{
+		"FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+		"FREQ=YEARLY;UNTIL=20241231;BYMONTH=1;BYDAY=SU,MO",
+		"FREQ=WEEKLY;UNTIL=19971224T000000Z;BYDAY=TU;WKST=SU",
+		"FREQ=DAILY;BYHOUR=9;BYMINUTE=0,30;BYSETPOS=1",
+	} {
+		r, err := time.ParseRRule("RRULE:" + s)
+		if err != nil || r.String() != s {
+			t.Errorf("ParseRRule(%s).String() = %s, %v", s, r.String(), err)
+		}
+	}
+	for _, bad := range []string{"", "COUNT=3", "FREQ=FORTNIGHTLY", "FREQ=DAILY;COUNT=0", "FREQ=DAILY;BYMONTH=13",
+		"FREQ=DAILY;BYDAY=0MO", "FREQ=DAILY;COUNT=2;UNTIL=20240101", "FREQ=DAILY;UNTIL=2024-01-01", "FREQ=DAILY;FOO=1"} {
+		if _, err := time.ParseRRule(bad); err == nil {
+			t.Errorf("ParseRRule(%q) should return error", bad)
+		}
+	}
+}
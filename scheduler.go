@@ -0,0 +1,282 @@
+package time
+
+import (
+	"slices"
+	"sync"
+
+	. "github.com/tinywasm/fmt"
+)
+
+// Schedule computes run times. *Cron and Every implement it.
+type Schedule interface {
+	// Next returns the first run strictly after the UnixNano instant after,
+	// or false when there are no more runs.
+	Next(after int64) (int64, bool)
+}
+
+// Every is a Schedule that runs at a fixed interval in nanoseconds,
+// measured from the previous planned run.
+type Every int64
+
+// Next returns after plus the interval.
+func (e Every) Next(after int64) (int64, bool) {
+	if e <= 0 {
+		return 0, false
+	}
+	return after + int64(e), true
+}
+
+// ParseSchedule parses "@every <duration>" (e.g. "@every 90s", "@every
+// 1h30m", "@every PT15M") into an Every, and anything else as a cron
+// expression evaluated in loc.
+func ParseSchedule(spec string, loc *Location) (Schedule, error) {
+	expr := TrimSpace(spec)
+	if len(expr) > 7 && ToLower(expr[:7]) == "@every " {
+		d, err := parseEvery(TrimSpace(expr[7:]))
+		if err != nil {
+			return nil, err
+		}
+		return Every(d), nil
+	}
+	return ParseCron(spec, loc)
+}
+
+// parseEvery parses a positive duration written as "1h30m", "90s", "250ms",
+// "2d" or an ISO 8601 duration.
+func parseEvery(s string) (int64, error) {
+	if s != "" && s[0] == 'P' {
+		d, err := parseICalDuration(s)
+		if err != nil || d <= 0 {
+			return 0, Errf("invalid interval: %s", s)
+		}
+		return d, nil
+	}
+	var total int64
+	for i := 0; i < len(s); {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		n, ok := parseDigits(s[start:i])
+		unitStart := i
+		for i < len(s) && (s[i] < '0' || s[i] > '9') {
+			i++
+		}
+		var unit int64
+		switch s[unitStart:i] {
+		case "ms":
+			unit = nanosPerSecond / 1000
+		case "s":
+			unit = nanosPerSecond
+		case "m":
+			unit = secondsPerMinute * nanosPerSecond
+		case "h":
+			unit = secondsPerHour * nanosPerSecond
+		case "d":
+			unit = secondsPerDay * nanosPerSecond
+		}
+		if !ok || unit == 0 {
+			return 0, Errf("invalid interval: %s", s)
+		}
+		total += int64(n) * unit
+	}
+	if total <= 0 {
+		return 0, Errf("invalid interval: %s", s)
+	}
+	return total, nil
+}
+
+// MissedRuns selects what a job does with runs that fell due while the
+// process was suspended, asleep or too busy to fire on time.
+type MissedRuns int
+
+const (
+	// SkipMissed drops late runs and waits for the next future run.
+	SkipMissed MissedRuns = iota
+	// RunMissedOnce runs once for any number of late runs.
+	RunMissedOnce
+	// RunAllMissed runs once per late run (at most maxCatchUp times).
+	RunAllMissed
+)
+
+const (
+	// maxCatchUp bounds the runs made by RunAllMissed after a long suspend.
+	maxCatchUp = 100
+	// maxArmMillis caps each timer so the wall clock is checked again at
+	// least once a minute; timers do not advance while a machine sleeps.
+	maxArmMillis = 60 * 1000
+	// defaultTolerance is how late a run may fire before it counts as missed.
+	defaultTolerance = nanosPerSecond
+)
+
+// Job is a named task run by a Scheduler.
+type Job struct {
+	Name      string
+	Schedule  Schedule
+	Run       func()
+	Missed    MissedRuns
+	Tolerance int64 // nanoseconds a run may be late before it counts as missed; 0 means one second
+}
+
+// Scheduler runs jobs at the times given by their schedules. Each job is a
+// single timer re-armed through AfterFunc after every run, so it never
+// blocks: on the backend jobs run on timer goroutines, in WASM on the
+// JavaScript event loop. A run that falls due while the previous run of
+// the same job is still in progress is skipped.
+type Scheduler struct {
+	mu   sync.Mutex
+	jobs map[string]*scheduledJob
+}
+
+type scheduledJob struct {
+	Job
+	next    int64
+	timer   Timer
+	running bool
+	removed bool
+}
+
+// NewScheduler returns an empty Scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{jobs: map[string]*scheduledJob{}}
+}
+
+// Add registers and arms a job. Names must be unique.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return Errf("scheduler: job needs a name, a schedule and a function")
+	}
+	now := Now()
+	next, ok := job.Schedule.Next(now)
+	if !ok {
+		return Errf("scheduler: job %s never runs", job.Name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[job.Name]; exists {
+		return Errf("scheduler: job %s already exists", job.Name)
+	}
+	j := &scheduledJob{Job: job, next: next}
+	s.jobs[job.Name] = j
+	s.arm(j, now)
+	return nil
+}
+
+// Remove cancels a job. A run in progress is not interrupted.
+func (s *Scheduler) Remove(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[name]
+	if ok {
+		s.cancel(j)
+	}
+	return ok
+}
+
+// Stop cancels every job. The Scheduler can be reused afterwards.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		s.cancel(j)
+	}
+}
+
+// Next returns the next planned run of a job.
+func (s *Scheduler) Next(name string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j, ok := s.jobs[name]; ok {
+		return j.next, true
+	}
+	return 0, false
+}
+
+// Names returns the registered job names in order.
+func (s *Scheduler) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// cancel stops and unregisters j; s.mu must be held.
+func (s *Scheduler) cancel(j *scheduledJob) {
+	j.removed = true
+	if j.timer != nil {
+		j.timer.Stop()
+	}
+	delete(s.jobs, j.Name)
+}
+
+// arm sets j's timer for its next run; s.mu must be held.
+func (s *Scheduler) arm(j *scheduledJob, now int64) {
+	wait := (j.next - now + 999_999) / 1_000_000
+	ms := int(min(max(wait, 0), maxArmMillis))
+	j.timer = AfterFunc(ms, func() { s.fire(j) })
+}
+
+// fire runs j if it is due and re-arms it.
+func (s *Scheduler) fire(j *scheduledJob) {
+	now := Now()
+	s.mu.Lock()
+	if j.removed {
+		s.mu.Unlock()
+		return
+	}
+	if now < j.next { // woken early to check the clock
+		s.arm(j, now)
+		s.mu.Unlock()
+		return
+	}
+
+	due, next, ok := 0, j.next, true
+	for ok && next <= now {
+		if due++; due > maxCatchUp {
+			next, ok = j.Schedule.Next(now)
+			due = maxCatchUp
+			break
+		}
+		next, ok = j.Schedule.Next(next)
+	}
+	tolerance := j.Tolerance
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	runs := 0
+	switch {
+	case due == 1 && now-j.next <= tolerance:
+		runs = 1
+	case j.Missed == RunMissedOnce:
+		runs = 1
+	case j.Missed == RunAllMissed:
+		runs = due
+	}
+	if j.running {
+		runs = 0
+	}
+	j.running = j.running || runs > 0
+	if ok {
+		j.next = next
+		s.arm(j, now)
+	} else {
+		s.cancel(j)
+	}
+	s.mu.Unlock()
+
+	if runs == 0 {
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		j.running = false
+		s.mu.Unlock()
+	}()
+	for range runs {
+		j.Run()
+	}
+}
@@ -0,0 +1,174 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test schedule parsing, scheduler bookkeeping and run policies on a FakeClock
+func SchedulerShared(t *testing.T) {
+	for spec, want := range map[string]int64{
+		"@every 90s":    90e9,
+		"@every 1h30m":  5400e9,
+		"@every 250ms":  250e6,
+		"@every 2d":     2 * 86400e9,
+		"@every PT15M":  900e9,
+		" @EVERY 1m30s": 90e9,
+	} {
+		s, err := time.ParseSchedule(spec, time.UTC)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", spec, err)
+			continue
+		}
+		if next, ok := s.Next(1000); !ok || next != 1000+want {
+			t.Errorf("ParseSchedule(%q).Next(1000) = %d, %v; want %d", spec, next, ok, 1000+want)
+		}
+	}
+	for _, bad := range []string{"@every", "@every 0s", "@every 5x", "@every m", "@every 5", "@every PT0S", "* * *"} {
+		if _, err := time.ParseSchedule(bad, time.UTC); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", bad)
+		}
+	}
+	s, err := time.ParseSchedule("0 0 1 1 *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(*time.Cron); !ok {
+		t.Errorf("ParseSchedule(cron) = %T; want *time.Cron", s)
+	}
+
+	sch := time.NewScheduler()
+	defer sch.Stop()
+	noop := func() {}
+	if err := sch.Add(time.Job{Name: "purge", Schedule: s, Run: noop}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sch.Add(time.Job{Name: "ping", Schedule: time.Every(3600e9), Run: noop}); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []time.Job{
+		{Name: "purge", Schedule: s, Run: noop},
+		{Name: "", Schedule: s, Run: noop},
+		{Name: "x", Run: noop},
+		{Name: "x", Schedule: s},
+		{Name: "x", Schedule: time.Every(0), Run: noop},
+	} {
+		if err := sch.Add(bad); err == nil {
+			t.Errorf("Add(%+v) should fail", bad)
+		}
+	}
+	if names := sch.Names(); len(names) != 2 || names[0] != "ping" || names[1] != "purge" {
+		t.Errorf("Names = %v", names)
+	}
+	next, ok := sch.Next("purge")
+	if !ok || time.TimeOf(next).UTC().Format("01-02 15:04") != "01-01 00:00" {
+		t.Errorf("Next(purge) = %d, %v", next, ok)
+	}
+	if !sch.Remove("purge") || sch.Remove("purge") {
+		t.Error("Remove should succeed once")
+	}
+	if _, ok := sch.Next("purge"); ok {
+		t.Error("removed job still scheduled")
+	}
+	sch.Stop()
+	if len(sch.Names()) != 0 {
+		t.Errorf("Names after Stop = %v", sch.Names())
+	}
+
+	// Runs on the fake clock: exact counts, nothing after Remove.
+	clock := time.NewFakeClock(1705361400000000000)
+	time.SetClock(clock)
+	defer time.SetClock(nil)
+	runs := 0
+	if err := sch.Add(time.Job{Name: "tick", Schedule: time.Every(20e6), Run: func() { runs++ }}); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(110e6)
+	if !sch.Remove("tick") || runs != 5 {
+		t.Errorf("runs = %d; want 5", runs)
+	}
+	clock.Advance(60e6)
+	if runs != 5 || clock.PendingTimers() != 0 {
+		t.Errorf("runs = %d, pending = %d after Remove", runs, clock.PendingTimers())
+	}
+
+	// A run due while the previous one is in progress is skipped. The job
+	// takes 25ms of fake time, so of the runs due every 10ms only those at
+	// 10, 40 and 70ms start.
+	active, maxActive := 0, 0
+	runs = 0
+	slow := func() {
+		active++
+		maxActive = max(maxActive, active)
+		runs++
+		clock.Advance(25e6)
+		active--
+	}
+	if err := sch.Add(time.Job{Name: "slow", Schedule: time.Every(10e6), Run: slow}); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(95e6)
+	sch.Stop()
+	if runs != 3 || maxActive != 1 {
+		t.Errorf("overlapping job: runs = %d, max concurrent = %d; want 3, 1", runs, maxActive)
+	}
+
+	// Missed runs: the timer fires late, as after a suspend.
+	for _, tc := range []struct {
+		policy time.MissedRuns
+		lag    int // milliseconds the timer fires late
+		times  int
+		want   int
+	}{
+		{time.SkipMissed, 60, 3, 0},
+		{time.RunMissedOnce, 60, 3, 1},
+		{time.RunAllMissed, 60, 3, 3},
+		{time.SkipMissed, 60, 1, 1},   // within the default one-second tolerance
+		{time.SkipMissed, 2000, 1, 0}, // beyond it
+		{time.RunMissedOnce, 2000, 1, 1},
+	} {
+		late := lateClock{clock, tc.lag}
+		time.SetClock(late)
+		base := clock.Now() + 20e6
+		var list listSchedule
+		for i := range tc.times {
+			list = append(list, base+int64(i))
+		}
+		runs = 0
+		err := sch.Add(time.Job{Name: "late", Schedule: list, Run: func() { runs++ }, Missed: tc.policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock.Advance(int64(tc.lag+100) * 1e6)
+		if runs != tc.want {
+			t.Errorf("policy %d, %d runs %dms late: runs = %d; want %d", tc.policy, tc.times, tc.lag, runs, tc.want)
+		}
+		if len(sch.Names()) != 0 {
+			t.Errorf("policy %d: finished job still registered", tc.policy)
+		}
+	}
+}
+
+// listSchedule runs at fixed instants.
+type listSchedule []int64
+
+func (l listSchedule) Next(after int64) (int64, bool) {
+	for _, n := range l {
+		if n > after {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// lateClock fires its timers lag milliseconds after their deadline, like a
+// timer that did not advance while the machine was suspended.
+type lateClock struct {
+	*time.FakeClock
+	lag int
+}
+
+func (c lateClock) AfterFunc(milliseconds int, f func()) time.Timer {
+	return c.FakeClock.AfterFunc(milliseconds+c.lag, f)
+}
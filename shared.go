@@ -21,9 +21,26 @@ func parseTime(timeStr string) (int16, error) {
 	return int16(hours*60 + minutes), nil
 }
 
-// DaysBetweenShared is a shared helper function for calculating the number of full days between two timestamps.
+// DaysBetweenShared returns the number of elapsed 24-hour periods between two
+// timestamps, ignoring calendar dates and timezones. See DaysBetween for calendar days.
 func DaysBetweenShared(nano1, nano2 int64) int {
 	// 86400000000000 nanoseconds in a day
 	const nanosInDay = 86400000000000
 	return int((nano2 - nano1) / nanosInDay)
 }
+
+// parseDigits converts a string made only of ASCII digits into an int.
+func parseDigits(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
@@ -0,0 +1,71 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+// Slot is a UTC UnixNano range [Start, End).
+type Slot = Interval
+
+// SlotRequest describes the appointment slots to generate.
+type SlotRequest struct {
+	Calendar     *WorkCalendar // working intervals and timezone
+	From, To     Date          // inclusive date range in the calendar location
+	Length       int           // slot length in minutes
+	Step         int           // minutes between slot starts; 0 means Length
+	BufferBefore int           // free minutes required before a booking
+	BufferAfter  int           // free minutes required after a booking
+	Booked       []Slot        // existing reservations
+}
+
+// FreeSlots returns the free slots of req sorted by start. Slots are aligned
+// to the start of each opening and never cross its end; a slot belongs to
+// the date its start falls on, so an overnight opening from the day before
+// From offers its slots after midnight. All arithmetic is integer UnixNano,
+// so WASM and the backend produce identical output.
+func FreeSlots(req SlotRequest) ([]Slot, error) {
+	if req.Calendar == nil {
+		return nil, Errf("slot request without calendar")
+	}
+	if req.Length <= 0 || req.Step < 0 || req.BufferBefore < 0 || req.BufferAfter < 0 {
+		return nil, Errf("invalid slot length, step or buffer")
+	}
+	if !req.From.IsValid() || !req.To.IsValid() || req.To.Before(req.From) {
+		return nil, Errf("invalid slot date range: %s - %s", req.From.String(), req.To.String())
+	}
+	const nanosPerMinute = secondsPerMinute * nanosPerSecond
+	length := int64(req.Length) * nanosPerMinute
+	step := int64(req.Step) * nanosPerMinute
+	if step == 0 {
+		step = length
+	}
+	before := int64(req.BufferBefore) * nanosPerMinute
+	after := int64(req.BufferAfter) * nanosPerMinute
+
+	first := req.Calendar.at(req.From, 0)
+	end := req.Calendar.at(req.To.AddDays(1), 0)
+
+	var out []Slot
+	last := int64(-1 << 63)
+	for _, s := range req.Calendar.spans(req.From.AddDays(-1), req.To) {
+		for start := s.Start; start+length <= s.End; start += step {
+			if start < first || start >= end || start <= last || slotBooked(req.Booked, start, start+length, before, after) {
+				continue
+			}
+			out = append(out, Slot{Start: start, End: start + length})
+			last = start
+		}
+	}
+	return out, nil
+}
+
+// slotBooked reports whether [start, end) overlaps a booking widened by its buffers.
+func slotBooked(booked []Slot, start, end, before, after int64) bool {
+	slot := Interval{Start: start, End: end}
+	for _, b := range booked {
+		if slot.Overlaps(Interval{Start: b.Start - before, End: b.End + after}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test appointment slot generation
+func SlotsShared(t *testing.T) {
+	loc := time.FixedZone("CLT", -4*3600)
+	wc := time.NewWorkCalendar(loc)
+	wc.SetHours(time.Monday, time.MinuteRange{Start: 9 * 60, End: 12 * 60})
+	wc.SetHours(time.Tuesday, time.MinuteRange{Start: 9 * 60, End: 10*60 + 30})
+	at := func(s string) int64 {
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dt.UnixNanoOffset(-4 * 3600)
+	}
+	format := func(slots []time.Slot) []string {
+		out := make([]string, len(slots))
+		for i, s := range slots {
+			out[i] = time.TimeOf(s.Start).In(loc).Format("01-02 15:04") + "-" + time.TimeOf(s.End).In(loc).Format("15:04")
+		}
+		return out
+	}
+
+	req := time.SlotRequest{
+		Calendar:    wc,
+		From:        time.Date{Year: 2024, Month: 5, Day: 20},
+		To:          time.Date{Year: 2024, Month: 5, Day: 21},
+		Length:      30,
+		BufferAfter: 15,
+		Booked:      []time.Slot{{Start: at("2024-05-20 10:00"), End: at("2024-05-20 10:45")}},
+	}
+	slots, err := time.FreeSlots(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"05-20 09:00-09:30", "05-20 09:30-10:00", "05-20 11:00-11:30", "05-20 11:30-12:00",
+		"05-21 09:00-09:30", "05-21 09:30-10:00", "05-21 10:00-10:30",
+	}
+	got := format(slots)
+	if len(got) != len(want) {
+		t.Fatalf("FreeSlots = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FreeSlots[%d] = %s; want %s", i, got[i], want[i])
+		}
+	}
+	if slots[0].Start != 1716210000*int64(1000000000) {
+		t.Errorf("slot start = %d; want UTC UnixNano of 13:00Z", slots[0].Start)
+	}
+
+	// 60-minute slots every 30 minutes with a buffer before bookings
+	req.Length, req.Step, req.BufferAfter, req.BufferBefore = 60, 30, 0, 30
+	req.To = req.From
+	got = format(mustSlots(t, req))
+	if len(got) != 1 || got[0] != "05-20 11:00-12:00" {
+		t.Errorf("FreeSlots(step) = %v", got)
+	}
+
+	// An overnight opening from the day before From offers its slots after midnight
+	night := time.NewWorkCalendar(loc)
+	night.SetHours(time.Sunday, time.MinuteRange{Start: 22 * 60, End: 1 * 60})
+	got = format(mustSlots(t, time.SlotRequest{Calendar: night, From: req.From, To: req.From, Length: 30}))
+	if len(got) != 2 || got[0] != "05-20 00:00-00:30" || got[1] != "05-20 00:30-01:00" {
+		t.Errorf("FreeSlots(overnight) = %v", got)
+	}
+	got = format(mustSlots(t, time.SlotRequest{Calendar: night, From: req.From.AddDays(-1), To: req.From.AddDays(-1), Length: 60}))
+	if len(got) != 2 || got[0] != "05-19 22:00-23:00" || got[1] != "05-19 23:00-00:00" {
+		t.Errorf("FreeSlots(overnight start) = %v", got)
+	}
+
+	for _, bad := range []time.SlotRequest{
+		{From: req.From, To: req.To, Length: 30},
+		{Calendar: wc, From: req.From, To: req.To},
+		{Calendar: wc, From: req.To.AddDays(1), To: req.To, Length: 30},
+	} {
+		if _, err := time.FreeSlots(bad); err == nil {
+			t.Errorf("FreeSlots(%+v) should return error", bad)
+		}
+	}
+}
+
+func mustSlots(t *testing.T, req time.SlotRequest) []time.Slot {
+	t.Helper()
+	slots, err := time.FreeSlots(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return slots
+}
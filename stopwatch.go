@@ -0,0 +1,92 @@
+package time
+
+import "sync"
+
+// Stopwatch measures elapsed time in nanoseconds on the Monotonic clock,
+// so system clock changes do not affect it. The zero value is stopped at
+// zero. A Stopwatch is safe for concurrent use.
+type Stopwatch struct {
+	mu      sync.Mutex
+	running bool
+	since   int64 // Monotonic reading at the last Start
+	elapsed int64 // time accumulated before the last Start
+	lapMark int64 // elapsed time when the current lap began
+	laps    []int64
+}
+
+// StartStopwatch returns a running Stopwatch.
+func StartStopwatch() *Stopwatch {
+	s := &Stopwatch{}
+	s.Start()
+	return s
+}
+
+// current returns the elapsed time; s.mu must be held.
+func (s *Stopwatch) current() int64 {
+	if !s.running {
+		return s.elapsed
+	}
+	return s.elapsed + max(Monotonic()-s.since, 0)
+}
+
+// Start starts or resumes the stopwatch. It does nothing if it is running.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		s.running = true
+		s.since = Monotonic()
+	}
+}
+
+// Stop pauses the stopwatch and returns the elapsed time. Start resumes it.
+func (s *Stopwatch) Stop() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elapsed = s.current()
+	s.running = false
+	return s.elapsed
+}
+
+// Reset stops the stopwatch and clears the elapsed time and laps.
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.elapsed = 0
+	s.lapMark = 0
+	s.laps = nil
+}
+
+// Elapsed returns the total running time, including the current run.
+func (s *Stopwatch) Elapsed() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current()
+}
+
+// Running reports whether the stopwatch is running.
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Lap ends the current lap, records it and returns its length: the running
+// time since the previous Lap, or since the start for the first one.
+func (s *Stopwatch) Lap() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.current()
+	lap := now - s.lapMark
+	s.lapMark = now
+	s.laps = append(s.laps, lap)
+	return lap
+}
+
+// Laps returns the recorded lap lengths in order.
+func (s *Stopwatch) Laps() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.laps...)
+}
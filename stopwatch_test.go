@@ -0,0 +1,75 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test the monotonic clock and the stopwatch
+func StopwatchShared(t *testing.T) {
+	// The real monotonic clock never goes back and resolves below a millisecond.
+	prev := time.Monotonic()
+	step := int64(0)
+	for i := 0; i < 1_000_000 && step == 0; i++ {
+		now := time.Monotonic()
+		if now < prev {
+			t.Fatalf("Monotonic went back: %d after %d", now, prev)
+		}
+		step, prev = now-prev, now
+	}
+	if step <= 0 || step >= 1_000_000 {
+		t.Errorf("Monotonic step = %dns; want a sub-millisecond step", step)
+	}
+
+	clock := time.NewFakeClock(1705329045000000000)
+	time.SetClock(clock)
+	defer time.SetClock(nil)
+
+	var zero time.Stopwatch
+	if zero.Running() || zero.Elapsed() != 0 {
+		t.Error("zero Stopwatch should be stopped at zero")
+	}
+
+	sw := time.StartStopwatch()
+	clock.Advance(1_500_000) // 1.5ms
+	if got := sw.Elapsed(); got != 1_500_000 || !sw.Running() {
+		t.Errorf("Elapsed = %d; want 1500000", got)
+	}
+	if got := sw.Lap(); got != 1_500_000 {
+		t.Errorf("first Lap = %d; want 1500000", got)
+	}
+	clock.Advance(250_000)
+	if got := sw.Stop(); got != 1_750_000 || sw.Running() {
+		t.Errorf("Stop = %d; want 1750000", got)
+	}
+
+	// Stopped time is not counted.
+	clock.Advance(5e9)
+	sw.Start()
+	sw.Start() // no-op while running
+	clock.Advance(750_000)
+	if got := sw.Lap(); got != 1_000_000 {
+		t.Errorf("second Lap = %d; want 1000000", got)
+	}
+	if laps := sw.Laps(); len(laps) != 2 || laps[0] != 1_500_000 || laps[1] != 1_000_000 {
+		t.Errorf("Laps = %v", laps)
+	}
+	if got := sw.Elapsed(); got != 2_500_000 {
+		t.Errorf("Elapsed = %d; want 2500000", got)
+	}
+	clock.Set(clock.Now() - 3600e9)
+	if got := sw.Elapsed(); got != 2_500_000 {
+		t.Errorf("Elapsed after clock moved back = %d; want 2500000", got)
+	}
+
+	sw.Reset()
+	if sw.Running() || sw.Elapsed() != 0 || len(sw.Laps()) != 0 {
+		t.Error("Reset should stop and clear the stopwatch")
+	}
+	sw.Start()
+	clock.Advance(10)
+	if got := sw.Lap(); got != 10 {
+		t.Errorf("Lap after Reset = %d; want 10", got)
+	}
+}
@@ -0,0 +1,90 @@
+package time
+
+import "sync"
+
+// TickFuncCompensated calls f every milliseconds, keeping the ticks on a
+// fixed grid measured from the start: each tick re-arms a one-shot timer
+// for the next grid point, so callback time and timer lateness do not
+// accumulate. Ticks that are missed entirely (a throttled background tab,
+// a suspended process) or that come while f is still running are dropped
+// rather than run in a burst.
+func TickFuncCompensated(milliseconds int, f func()) Ticker {
+	t := &compensatedTicker{f: f}
+	t.Reset(milliseconds)
+	return t
+}
+
+type compensatedTicker struct {
+	mu      sync.Mutex
+	f       func()
+	period  int64 // nanoseconds
+	next    int64 // UnixNano of the next tick
+	timer   Timer
+	gen     int // invalidates timers armed before a Stop or Reset
+	active  bool
+	running bool // f is running; ticks are dropped until it returns
+}
+
+func (t *compensatedTicker) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active {
+		return false
+	}
+	t.active = false
+	t.gen++
+	t.timer.Stop()
+	return true
+}
+
+func (t *compensatedTicker) Reset(milliseconds int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active {
+		t.timer.Stop()
+	}
+	now := Now()
+	t.period = int64(max(milliseconds, 1)) * 1_000_000
+	t.next = now + t.period
+	t.active = true
+	t.gen++
+	t.arm(now)
+}
+
+// arm sets the timer for t.next; t.mu must be held.
+func (t *compensatedTicker) arm(now int64) {
+	gen := t.gen
+	wait := int((t.next - now + 999_999) / 1_000_000)
+	t.timer = AfterFunc(max(wait, 0), func() { t.fire(gen) })
+}
+
+func (t *compensatedTicker) fire(gen int) {
+	now := Now()
+	t.mu.Lock()
+	if !t.active || gen != t.gen {
+		t.mu.Unlock()
+		return
+	}
+	if now < t.next { // fired early
+		t.arm(now)
+		t.mu.Unlock()
+		return
+	}
+	t.next += t.period
+	if t.next <= now {
+		t.next += ((now-t.next)/t.period + 1) * t.period
+	}
+	t.arm(now)
+	if t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = true
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.running = false
+		t.mu.Unlock()
+	}()
+	t.f()
+}
@@ -0,0 +1,125 @@
+//go:build !wasm
+
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// timerWrapper and tickerWrapper are the backend's runtime-backed timers.
+type timerWrapper struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	deadline time.Time
+	pending  bool
+}
+
+func (tw *timerWrapper) Stop() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.pending = false
+	return tw.timer.Stop()
+}
+
+func (tw *timerWrapper) Reset(milliseconds int) bool {
+	d := time.Duration(milliseconds) * time.Millisecond
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.deadline, tw.pending = time.Now().Add(d), true
+	return tw.timer.Reset(d)
+}
+
+func (tw *timerWrapper) Pending() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.pending
+}
+
+func (tw *timerWrapper) Remaining() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if !tw.pending {
+		return 0
+	}
+	left := time.Until(tw.deadline)
+	return int(max(left+time.Millisecond-1, 0) / time.Millisecond)
+}
+
+// fired marks the timer as expired unless a Reset moved the deadline.
+func (tw *timerWrapper) fired() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if !time.Now().Before(tw.deadline) {
+		tw.pending = false
+	}
+}
+
+func newStdTimer(milliseconds int, f func()) Timer {
+	d := time.Duration(milliseconds) * time.Millisecond
+	tw := &timerWrapper{deadline: time.Now().Add(d), pending: true}
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timer = time.AfterFunc(d, func() {
+		tw.fired()
+		f()
+	})
+	return tw
+}
+
+type tickerWrapper struct {
+	mu     sync.Mutex
+	ticker *time.Ticker
+	done   chan struct{} // nil when stopped
+	f      func()
+}
+
+// Stop stops the ticker goroutine. Returns true if the ticker was active.
+func (tw *tickerWrapper) Stop() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.done == nil {
+		return false
+	}
+	tw.ticker.Stop()
+	close(tw.done)
+	tw.done = nil
+	return true
+}
+
+// Reset changes the period and restarts the ticks from now.
+func (tw *tickerWrapper) Reset(milliseconds int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.ticker.Reset(time.Duration(max(milliseconds, 1)) * time.Millisecond)
+	if tw.done == nil {
+		tw.done = make(chan struct{})
+		go tw.run(tw.done)
+	}
+}
+
+func (tw *tickerWrapper) run(done chan struct{}) {
+	for {
+		select {
+		case <-tw.ticker.C:
+			select {
+			case <-done: // stopped while the tick was pending
+				return
+			default:
+			}
+			tw.f()
+		case <-done:
+			return
+		}
+	}
+}
+
+func newStdTicker(milliseconds int, f func()) Ticker {
+	tw := &tickerWrapper{
+		ticker: time.NewTicker(time.Duration(max(milliseconds, 1)) * time.Millisecond),
+		done:   make(chan struct{}),
+		f:      f,
+	}
+	go tw.run(tw.done)
+	return tw
+}
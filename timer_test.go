@@ -23,3 +23,35 @@ func AfterFuncStopVerify(t *testing.T, executed *bool) {
 	}
 	t.Log("AfterFunc_Stop test passed")
 }
+
+// TickerPeriodShared tests that a zero or negative period ticks every
+// millisecond on every platform instead of panicking.
+func TickerPeriodShared(t *testing.T) {
+	for _, ms := range []int{0, -5} {
+		ticker := time.TickFunc(ms, func() {})
+		ticker.Reset(ms)
+		if !ticker.Stop() {
+			t.Errorf("TickFunc(%d): Stop() should report an active ticker", ms)
+		}
+		ticker.Reset(ms)
+		ticker.Stop()
+		compensated := time.TickFuncCompensated(ms, func() {})
+		compensated.Reset(ms)
+		if !compensated.Stop() {
+			t.Errorf("TickFuncCompensated(%d): Stop() should report an active ticker", ms)
+		}
+	}
+
+	clock := time.NewFakeClock(0)
+	time.SetClock(clock)
+	defer time.SetClock(nil)
+	ticks := 0
+	ticker := time.TickFunc(0, func() { ticks++ })
+	clock.Advance(3_000_000)
+	ticker.Reset(-5)
+	clock.Advance(2_000_000)
+	ticker.Stop()
+	if ticks != 5 {
+		t.Errorf("fake ticks = %d; want one per millisecond (5)", ticks)
+	}
+}
@@ -0,0 +1,158 @@
+package time
+
+// Time is an instant with nanosecond precision plus an optional Location
+// used by its calendar accessors and Format. It is a small value type
+// (UnixNano + pointer) and behaves the same on every provider.
+// A nil location means Local; the zero value is the Unix epoch.
+type Time struct {
+	nano int64
+	loc  *Location
+}
+
+// NowTime returns the current time in Local.
+func NowTime() Time {
+	return Time{nano: Now()}
+}
+
+// TimeOf wraps a UnixNano timestamp into a Time in Local.
+func TimeOf(nano int64) Time {
+	return Time{nano: nano}
+}
+
+// Unix returns the Time for sec seconds and nsec nanoseconds since the Unix epoch, in Local.
+func Unix(sec, nsec int64) Time {
+	return Time{nano: sec*nanosPerSecond + nsec}
+}
+
+// In returns t with its location set to loc.
+func (t Time) In(loc *Location) Time {
+	t.loc = loc
+	return t
+}
+
+// UTC returns t with its location set to UTC.
+func (t Time) UTC() Time { return t.In(UTC) }
+
+// Local returns t with its location set to Local.
+func (t Time) Local() Time { return t.In(Local) }
+
+// Location returns the location of t.
+func (t Time) Location() *Location {
+	if t.loc == nil {
+		return Local
+	}
+	return t.loc
+}
+
+// IsZero reports whether t is the Unix epoch.
+func (t Time) IsZero() bool { return t.nano == 0 }
+
+// UnixNano returns t as nanoseconds since the Unix epoch.
+func (t Time) UnixNano() int64 { return t.nano }
+
+// UnixMicro returns t as microseconds since the Unix epoch.
+func (t Time) UnixMicro() int64 { return floorDiv(t.nano, 1000) }
+
+// UnixMilli returns t as milliseconds since the Unix epoch.
+func (t Time) UnixMilli() int64 { return floorDiv(t.nano, 1000000) }
+
+// Unix returns t as seconds since the Unix epoch.
+func (t Time) Unix() int64 { return floorDiv(t.nano, nanosPerSecond) }
+
+// Add returns t moved by nanos nanoseconds.
+func (t Time) Add(nanos int64) Time {
+	t.nano += nanos
+	return t
+}
+
+// Sub returns the nanoseconds elapsed from u to t.
+func (t Time) Sub(u Time) int64 { return t.nano - u.nano }
+
+// Before reports whether t is before u.
+func (t Time) Before(u Time) bool { return t.nano < u.nano }
+
+// After reports whether t is after u.
+func (t Time) After(u Time) bool { return t.nano > u.nano }
+
+// Equal reports whether t and u are the same instant, regardless of location.
+func (t Time) Equal(u Time) bool { return t.nano == u.nano }
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or after u.
+func (t Time) Compare(u Time) int {
+	switch {
+	case t.nano < u.nano:
+		return -1
+	case t.nano > u.nano:
+		return 1
+	}
+	return 0
+}
+
+// Truncate rounds t down to a multiple of nanos since the Unix epoch.
+// Multiples of a day align with UTC midnight; use the calendar helpers
+// for local boundaries. nanos <= 0 returns t unchanged.
+func (t Time) Truncate(nanos int64) Time {
+	if nanos <= 0 {
+		return t
+	}
+	t.nano -= floorMod(t.nano, nanos)
+	return t
+}
+
+// DateTime returns the wall-clock date and time of t in its location.
+func (t Time) DateTime() DateTime {
+	return DateTimeOf(t.nano, t.loc)
+}
+
+// Date returns the year, month and day of t in its location.
+func (t Time) Date() (year int, month Month, day int) {
+	d := t.DateTime().Date
+	return d.Year, d.Month, d.Day
+}
+
+// Clock returns the hour, minute and second of t in its location.
+func (t Time) Clock() (hour, min, sec int) {
+	c := t.DateTime().Time
+	return c.Hour, c.Minute, c.Second
+}
+
+// Nanosecond returns the nanosecond offset within the second.
+func (t Time) Nanosecond() int {
+	_, nsec := splitNano(t.nano)
+	return int(nsec)
+}
+
+// Weekday returns the day of the week of t in its location.
+func (t Time) Weekday() DayOfWeek {
+	return t.DateTime().Date.Weekday()
+}
+
+// YearDay returns the day of the year of t in its location.
+func (t Time) YearDay() int {
+	return t.DateTime().Date.YearDay()
+}
+
+// Offset returns the offset of t's location in seconds east of UTC at t.
+func (t Time) Offset() int {
+	return t.loc.Offset(t.nano)
+}
+
+// Format renders t in its location using a stdlib-style reference layout
+// such as "2006-01-02 15:04:05" (see layout.go for the supported elements).
+func (t Time) Format(layout string) string {
+	offset := t.Offset()
+	return formatLayout(layout, t.DateTime(), offset, t.zoneName())
+}
+
+// String formats t as "2006-01-02 15:04:05.999999999 -07:00".
+func (t Time) String() string {
+	return t.Format("2006-01-02 15:04:05.999999999 -07:00")
+}
+
+// zoneName returns the name printed for MST: UTC and named fixed zones only.
+func (t Time) zoneName() string {
+	if t.loc != nil && t.loc.kind == locationFixed {
+		return t.loc.name
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+//go:build !wasm
+
+package time
+
+import "time"
+
+// Std converts t into a standard library time.Time in the equivalent location.
+func (t Time) Std() time.Time {
+	st := time.Unix(0, t.nano)
+	loc := t.loc
+	switch {
+	case loc == nil || loc.kind == locationActive:
+		return st.In(time.FixedZone("Local", int(localOffsetSeconds())))
+	case loc == UTC:
+		return st.UTC()
+	case loc.kind == locationFixed:
+		return st.In(time.FixedZone(loc.name, loc.offset))
+	}
+	if zone := loadZone(loc.name); zone != nil {
+		return st.In(zone)
+	}
+	return st.In(time.FixedZone(loc.name, t.Offset()))
+}
+
+// FromStd converts a standard library time.Time into a Time. Zones from
+// the tz database keep their IANA name; anything else, such as
+// time.FixedZone("CET", 3600), becomes a fixed zone with the name and
+// offset in effect at st, so the wall clock never changes.
+//
+// time.Local maps to Local, which follows the package's active offset
+// (see SetTimeZoneOffset) rather than the system zone's DST rules. Call
+// st.In with a named location first to keep those rules.
+func FromStd(st time.Time) Time {
+	t := Time{nano: st.UnixNano()}
+	switch loc := st.Location(); loc {
+	case time.UTC:
+		t.loc = UTC
+	case time.Local:
+		t.loc = Local
+	default:
+		name, offset := st.Zone()
+		t.loc = FixedZone(name, offset)
+		// Fixed zones have no transitions, so ZoneBounds is empty.
+		if start, end := st.ZoneBounds(); start.IsZero() && end.IsZero() {
+			break
+		}
+		if named, err := LoadLocation(loc.String()); err == nil && named.Offset(t.nano) == offset {
+			t.loc = named
+		}
+	}
+	return t
+}
@@ -0,0 +1,64 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test Time value type
+func TimeValueShared(t *testing.T) {
+	const s = int64(1000000000)
+	// 2024-01-15 15:30:45.123456789 UTC (a Monday)
+	tm := time.TimeOf(1705332645*s + 123456789).UTC()
+
+	if y, m, d := tm.Date(); y != 2024 || m != time.January || d != 15 {
+		t.Errorf("Date() = %d-%d-%d", y, m, d)
+	}
+	if h, m, sec := tm.Clock(); h != 15 || m != 30 || sec != 45 {
+		t.Errorf("Clock() = %d:%d:%d", h, m, sec)
+	}
+	if tm.Weekday() != time.Monday || tm.YearDay() != 15 || tm.Nanosecond() != 123456789 {
+		t.Errorf("Weekday/YearDay/Nanosecond = %v/%d/%d", tm.Weekday(), tm.YearDay(), tm.Nanosecond())
+	}
+	if tm.Unix() != 1705332645 || tm.UnixMilli() != 1705332645123 || tm.UnixMicro() != 1705332645123456 {
+		t.Error("Unix accessors failed")
+	}
+
+	// Arithmetic and comparisons
+	later := tm.Add(90 * s)
+	if later.Sub(tm) != 90*s || !tm.Before(later) || !later.After(tm) || tm.Compare(tm) != 0 {
+		t.Error("Add/Sub/Before/After failed")
+	}
+	if !tm.Equal(tm.In(time.FixedZone("X", 3600))) {
+		t.Error("Equal should ignore location")
+	}
+	if got := tm.Truncate(3600 * s); got.Format("15:04:05.000") != "15:00:00.000" {
+		t.Errorf("Truncate(1h) = %s", got.Format("15:04:05.000"))
+	}
+	if got := time.Unix(-1, 500000000).Truncate(s); got.Unix() != -1 {
+		t.Errorf("Truncate before epoch = %d; want -1", got.Unix())
+	}
+
+	// Formatting
+	cases := map[string]string{
+		"2006-01-02 15:04:05":                 "2024-01-15 15:30:45",
+		"Mon Jan _2 3:04PM":                   "Mon Jan 15 3:30PM",
+		"Monday, January 2, 2006":             "Monday, January 15, 2024",
+		"02/01/06 15h04 .000":                 "15/01/24 15h30 .123",
+		"2006-01-02T15:04:05.999999999Z07:00": "2024-01-15T15:30:45.123456789Z",
+		"day 002 pm MST":                      "day 015 pm UTC",
+	}
+	for layout, want := range cases {
+		if got := tm.Format(layout); got != want {
+			t.Errorf("Format(%q) = %q; want %q", layout, got, want)
+		}
+	}
+	santiago := tm.In(time.FixedZone("", -3*3600))
+	if got := santiago.Format("2006-01-02 15:04 -07:00 Z0700 MST"); got != "2024-01-15 12:30 -03:00 -0300 -0300" {
+		t.Errorf("Format(-03:00) = %q", got)
+	}
+	if got := santiago.String(); got != "2024-01-15 12:30:45.123456789 -03:00" {
+		t.Errorf("String() = %q", got)
+	}
+}
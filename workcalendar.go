@@ -0,0 +1,204 @@
+package time
+
+import (
+	. "github.com/tinywasm/fmt"
+)
+
+const minutesPerDay = 24 * 60
+
+// MinuteRange is an opening interval in minutes since midnight, the same
+// int16 format used by ParseTime / FormatTime (work_start, work_end).
+// End may be 1440 (24:00); End <= Start means the range crosses midnight
+// and ends on the next day.
+type MinuteRange struct {
+	Start int16
+	End   int16
+}
+
+// ParseMinuteRange parses "HH:MM-HH:MM"; the end may be "24:00".
+func ParseMinuteRange(s string) (MinuteRange, error) {
+	parts := Convert(s).Split("-")
+	if len(parts) != 2 {
+		return MinuteRange{}, Errf("invalid minute range: %s", s)
+	}
+	start, err := parseTime(TrimSpace(parts[0]))
+	if err != nil {
+		return MinuteRange{}, err
+	}
+	endStr := TrimSpace(parts[1])
+	end := int16(minutesPerDay)
+	if endStr != "24:00" {
+		if end, err = parseTime(endStr); err != nil {
+			return MinuteRange{}, err
+		}
+	}
+	return MinuteRange{Start: start, End: end}, nil
+}
+
+// IsValid reports whether Start is in [0, 1440) and End in [0, 1440].
+func (r MinuteRange) IsValid() bool {
+	return r.Start >= 0 && r.Start < minutesPerDay && r.End >= 0 && r.End <= minutesPerDay
+}
+
+// CrossesMidnight reports whether the range ends on the next day.
+func (r MinuteRange) CrossesMidnight() bool {
+	return r.End <= r.Start
+}
+
+// Minutes returns the length of the range in minutes.
+func (r MinuteRange) Minutes() int {
+	if r.CrossesMidnight() {
+		return int(r.End) + minutesPerDay - int(r.Start)
+	}
+	return int(r.End - r.Start)
+}
+
+// String formats the range as "HH:MM-HH:MM".
+func (r MinuteRange) String() string {
+	return Sprintf("%02d:%02d-%02d:%02d", r.Start/60, r.Start%60, r.End/60, r.End%60)
+}
+
+// WorkCalendar holds weekly opening hours with date-specific exceptions,
+// evaluated in a location. Ranges belong to the day they start on: closing
+// a date does not cut a range that started the evening before.
+type WorkCalendar struct {
+	Location   *Location // nil means Local
+	week       [7][]MinuteRange
+	exceptions map[Date][]MinuteRange
+}
+
+// NewWorkCalendar returns an always-closed calendar in loc.
+func NewWorkCalendar(loc *Location) *WorkCalendar {
+	return &WorkCalendar{Location: loc, exceptions: map[Date][]MinuteRange{}}
+}
+
+// SetHours replaces the weekly opening ranges of day; no ranges closes it.
+func (wc *WorkCalendar) SetHours(day DayOfWeek, ranges ...MinuteRange) error {
+	if !day.IsValid() {
+		return Errf("invalid day of week: %d", int(day))
+	}
+	if err := validRanges(ranges); err != nil {
+		return err
+	}
+	wc.week[day] = append([]MinuteRange(nil), ranges...)
+	return nil
+}
+
+// SetException replaces the weekly hours on a specific date.
+func (wc *WorkCalendar) SetException(d Date, ranges ...MinuteRange) error {
+	if !d.IsValid() {
+		return Errf("invalid date: %s", d.String())
+	}
+	if err := validRanges(ranges); err != nil {
+		return err
+	}
+	if wc.exceptions == nil {
+		wc.exceptions = map[Date][]MinuteRange{}
+	}
+	wc.exceptions[d] = append([]MinuteRange{}, ranges...)
+	return nil
+}
+
+// Close marks a date as closed (e.g. a holiday).
+func (wc *WorkCalendar) Close(d Date) {
+	wc.SetException(d)
+}
+
+// ClearException restores the weekly hours on d.
+func (wc *WorkCalendar) ClearException(d Date) {
+	delete(wc.exceptions, d)
+}
+
+// HoursOn returns the ranges starting on d, honouring exceptions.
+func (wc *WorkCalendar) HoursOn(d Date) []MinuteRange {
+	if ranges, ok := wc.exceptions[d]; ok {
+		return ranges
+	}
+	return wc.week[d.Weekday()]
+}
+
+// IsOpen reports whether the calendar is open at nano.
+func (wc *WorkCalendar) IsOpen(nano int64) bool {
+	d := DateTimeOf(nano, wc.Location).Date
+	for _, s := range wc.spans(d.AddDays(-1), d) {
+		if s.Contains(nano) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpening returns nano if the calendar is open at nano, otherwise the
+// start of the next opening. It reports false when nothing opens within ten years.
+func (wc *WorkCalendar) NextOpening(nano int64) (int64, bool) {
+	d := DateTimeOf(nano, wc.Location).Date
+	if wc.IsOpen(nano) {
+		return nano, true
+	}
+	for i := 0; i < maxNonBusinessRun; i++ {
+		for _, s := range wc.spans(d, d) {
+			if s.Start > nano {
+				return s.Start, true
+			}
+		}
+		d = d.AddDays(1)
+	}
+	return 0, false
+}
+
+// OpenMinutes returns the whole minutes the calendar is open in [a, b).
+// Overlapping ranges are counted once.
+func (wc *WorkCalendar) OpenMinutes(a, b int64) int {
+	if b <= a {
+		return 0
+	}
+	from := DateTimeOf(a, wc.Location).Date.AddDays(-1)
+	to := DateTimeOf(b, wc.Location).Date
+	open := NewIntervalSet(wc.spans(from, to)...).Clip(Interval{Start: a, End: b})
+	return int(open.Duration() / (secondsPerMinute * nanosPerSecond))
+}
+
+// Openings returns the opening intervals that start on the dates from..to.
+func (wc *WorkCalendar) Openings(from, to Date) IntervalSet {
+	return NewIntervalSet(wc.spans(from, to)...)
+}
+
+// spans returns the openings starting on the dates from..to, sorted by start.
+func (wc *WorkCalendar) spans(from, to Date) []Interval {
+	var out []Interval
+	for d := from; !d.After(to); d = d.AddDays(1) {
+		for _, r := range wc.HoursOn(d) {
+			endDate := d
+			if r.CrossesMidnight() {
+				endDate = d.AddDays(1)
+			}
+			s := Interval{Start: wc.at(d, r.Start), End: wc.at(endDate, r.End)}
+			i := len(out)
+			for i > 0 && out[i-1].Start > s.Start {
+				i--
+			}
+			out = append(out, Interval{})
+			copy(out[i+1:], out[i:])
+			out[i] = s
+		}
+	}
+	return out
+}
+
+// at resolves minutes since midnight on d (1440 is the next midnight).
+func (wc *WorkCalendar) at(d Date, minutes int16) int64 {
+	if minutes >= minutesPerDay {
+		d, minutes = d.AddDays(1), minutes-minutesPerDay
+	}
+	nano, _ := DateTime{Date: d, Time: TimeOfDayFromMinutes(minutes)}.UnixNanoIn(wc.Location, Compatible)
+	return nano
+}
+
+func validRanges(ranges []MinuteRange) error {
+	for _, r := range ranges {
+		if !r.IsValid() {
+			return Errf("invalid minute range: %d-%d", r.Start, r.End)
+		}
+	}
+	return nil
+}
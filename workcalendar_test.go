@@ -0,0 +1,103 @@
+package time_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/time"
+)
+
+// Test weekly opening hours
+func WorkCalendarShared(t *testing.T) {
+	scl, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(s string) int64 {
+		dt, err := time.ParseCivilDateTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nano, _ := dt.UnixNanoIn(scl, time.Compatible)
+		return nano
+	}
+	rng := func(s string) time.MinuteRange {
+		r, err := time.ParseMinuteRange(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	wc := time.NewWorkCalendar(scl)
+	for _, d := range []time.DayOfWeek{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+		if err := wc.SetHours(d, rng("09:00-13:00"), rng("14:00-18:00")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wc.SetHours(time.Saturday, rng("22:00-02:00")) // night shift into Sunday
+	wc.Close(time.Date{Year: 2024, Month: 5, Day: 21})
+	wc.SetException(time.Date{Year: 2024, Month: 5, Day: 22}, rng("10:00-12:00"))
+
+	if r := rng("22:00-02:00"); !r.CrossesMidnight() || r.Minutes() != 240 || r.String() != "22:00-02:00" {
+		t.Errorf("MinuteRange = %s, %d minutes", r, r.Minutes())
+	}
+	if r := rng("18:00-24:00"); r.End != 1440 || r.Minutes() != 360 {
+		t.Errorf("MinuteRange(24:00) = %+v", r)
+	}
+	if _, err := time.ParseMinuteRange("09:00"); err == nil {
+		t.Error("ParseMinuteRange without end should fail")
+	}
+	if err := wc.SetHours(time.Monday, time.MinuteRange{Start: 1500, End: 10}); err == nil {
+		t.Error("SetHours should reject out-of-range minutes")
+	}
+
+	open := []struct {
+		at   string
+		want bool
+	}{
+		{"2024-05-20 09:00", true},
+		{"2024-05-20 13:30", false},
+		{"2024-05-20 18:00", false},
+		{"2024-05-21 10:00", false}, // closed
+		{"2024-05-22 11:59", true},  // exception
+		{"2024-05-22 15:00", false},
+		{"2024-05-25 23:00", true},
+		{"2024-05-26 01:30", true}, // Saturday shift into Sunday
+		{"2024-05-26 02:00", false},
+	}
+	for _, c := range open {
+		if got := wc.IsOpen(at(c.at)); got != c.want {
+			t.Errorf("IsOpen(%s) = %v; want %v", c.at, got, c.want)
+		}
+	}
+
+	next := []struct{ from, want string }{
+		{"2024-05-20 08:00", "2024-05-20 09:00"},
+		{"2024-05-20 10:00", "2024-05-20 10:00"},
+		{"2024-05-20 13:10", "2024-05-20 14:00"},
+		{"2024-05-20 19:00", "2024-05-22 10:00"},
+		{"2024-05-26 03:00", "2024-05-27 09:00"},
+	}
+	for _, c := range next {
+		got, ok := wc.NextOpening(at(c.from))
+		if !ok || got != at(c.want) {
+			t.Errorf("NextOpening(%s) = %s; want %s", c.from, time.TimeOf(got).In(scl).Format("2006-01-02 15:04"), c.want)
+		}
+	}
+	if _, ok := time.NewWorkCalendar(time.UTC).NextOpening(0); ok {
+		t.Error("NextOpening on an empty calendar should report false")
+	}
+
+	// Mon 8h + Wed 2h + Thu 8h + Fri 8h + Sat night 4h (Tuesday closed)
+	if got := wc.OpenMinutes(at("2024-05-20 00:00"), at("2024-05-27 00:00")); got != 30*60 {
+		t.Errorf("OpenMinutes(week) = %d; want %d", got, 30*60)
+	}
+	if got := wc.OpenMinutes(at("2024-05-20 12:30"), at("2024-05-20 14:15")); got != 45 {
+		t.Errorf("OpenMinutes(partial) = %d; want 45", got)
+	}
+
+	// Chile's 2024-09-08 starts at 01:00: a Saturday night shift loses an hour
+	if got := wc.OpenMinutes(at("2024-09-07 00:00"), at("2024-09-09 00:00")); got != 180 {
+		t.Errorf("OpenMinutes(DST gap) = %d; want 180", got)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !wasm
+
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// zones caches loaded IANA locations; a nil value marks an unknown name.
+var zones sync.Map
+
+// loadZone returns the cached stdlib location for tz, or nil if unknown.
+func loadZone(tz string) *time.Location {
+	v, ok := zones.Load(tz)
+	if !ok {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			loc = nil
+		}
+		v, _ = zones.LoadOrStore(tz, loc)
+	}
+	return v.(*time.Location)
+}
+
+// stdZoneOffset resolves tz with the runtime's tz database.
+func stdZoneOffset(tz string, unixSec int64) (int, bool) {
+	loc := loadZone(tz)
+	if loc == nil {
+		return 0, false
+	}
+	_, offset := time.Unix(unixSec, 0).In(loc).Zone()
+	return offset, true
+}